@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/emilianotisato/vibeit/internal/config"
+	"github.com/emilianotisato/vibeit/internal/workspace"
+)
+
+// runConfig handles `vibeit config <show|validate|schema>`
+func runConfig(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: vibeit config <show|validate|schema> [file]")
+		return 1
+	}
+
+	switch args[0] {
+	case "show":
+		return showConfig()
+	case "validate":
+		return validateConfig()
+	case "schema":
+		name := "wt.json"
+		if len(args) > 1 {
+			name = args[1]
+		}
+		data, err := config.Schema(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "No schema for %s (available: %s)\n", name, strings.Join(config.Files(), ", "))
+			return 1
+		}
+		fmt.Print(string(data))
+		return 0
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown config command: %s\n", args[0])
+		return 1
+	}
+}
+
+func validateConfig() int {
+	repoPath, err := workspace.GetProjectPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	problems := append(config.ValidateGlobal(), config.Validate(repoPath)...)
+	for _, p := range problems {
+		fmt.Println(p)
+	}
+	if config.HasErrors(problems) {
+		return 1
+	}
+	if len(problems) == 0 {
+		fmt.Println("All config files are valid")
+	}
+	return 0
+}
+
+func showConfig() int {
+	repoPath, _ := workspace.GetProjectPath()
+	cfg, err := config.Load(repoPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	keys := cfg.Keys()
+	keyWidth, valueWidth := 0, 0
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = formatValue(cfg.Value(key))
+		keyWidth = max(keyWidth, len(key))
+		valueWidth = max(valueWidth, len(values[i]))
+	}
+
+	for i, key := range keys {
+		fmt.Printf("%-*s  %-*s  %s\n", keyWidth, key, valueWidth, values[i], cfg.Source(key))
+	}
+	return 0
+}
+
+func formatValue(value any) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
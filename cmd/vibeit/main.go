@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/emilianotisato/vibeit/internal/config"
 	"github.com/emilianotisato/vibeit/internal/doctor"
+	"github.com/emilianotisato/vibeit/internal/hooks"
 	"github.com/emilianotisato/vibeit/internal/mux"
 	"github.com/emilianotisato/vibeit/internal/tui"
+	"github.com/emilianotisato/vibeit/internal/workspace"
 )
 
 const version = "0.1.0"
@@ -16,16 +19,32 @@ func main() {
 		switch os.Args[1] {
 		case "doctor":
 			os.Exit(doctor.Run())
+		case "config":
+			os.Exit(runConfig(os.Args[2:]))
+		case "init":
+			os.Exit(runInit(os.Args[2:]))
+		case "remove":
+			os.Exit(runRemove(os.Args[2:]))
 		case "tmux-overview":
 			if err := mux.ToggleOverview(); err != nil {
 				fmt.Fprintln(os.Stderr, err)
 				os.Exit(1)
 			}
 			os.Exit(0)
+		case "run-hooks":
+			// Hooks handed off by a dashboard that exits right away (tmux popup)
+			if err := hooks.RunFromReader(os.Stdin); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+			os.Exit(0)
 		case "version", "--version", "-v":
 			fmt.Printf("vibeit %s\n", version)
 			os.Exit(0)
 		case "help", "--help", "-h":
+			if len(os.Args) > 2 && os.Args[2] == "keys" {
+				os.Exit(printKeys())
+			}
 			printHelp()
 			os.Exit(0)
 		default:
@@ -46,17 +65,35 @@ func printHelp() {
 	fmt.Println(`vibeit - Workspace-centric vibe coding TUI
 
 Usage:
-  vibeit              Launch the TUI in current directory
-  vibeit doctor       Check system dependencies
-  vibeit version      Show version
-  vibeit help         Show this help
-
-Keybindings (in TUI):
-  Ctrl+\              Enter command mode
-  1-9                 Switch workspace
-  n                   Open notes
-  g                   Open lazygit
-  t                   New terminal
-  w                   New workspace
-  q                   Quit / close tab`)
+  vibeit                        Launch the TUI in current directory
+  vibeit init                   Detect the stack and write .vibe/wt.json
+  vibeit config validate        Validate .vibe/*.json files
+  vibeit config schema [file]   Print the JSON Schema for a .vibe file
+  vibeit config show            Print effective settings and where they come from
+  vibeit doctor                 Check system dependencies
+  vibeit remove <N>             Run teardown and delete workspace {project}-wt-N
+  vibeit version                Show version
+  vibeit help keys              List keybindings for the current configuration
+  vibeit help                   Show this help
+
+Press ? in the TUI to see keybindings.`)
+}
+
+// printKeys handles `vibeit help keys`
+func printKeys() int {
+	projectPath, _ := workspace.GetProjectPath()
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	lines, err := tui.KeyHelp(cfg.Settings)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+	return 0
 }
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/emilianotisato/vibeit/internal/config"
+	"github.com/emilianotisato/vibeit/internal/mux"
+	"github.com/emilianotisato/vibeit/internal/workspace"
+	workspace_init "github.com/emilianotisato/vibeit/internal/workspace_init"
+)
+
+// runRemove handles `vibeit remove [--force] [--pause] <N|path>`
+func runRemove(args []string) int {
+	force := false
+	pause := false
+	target := ""
+	for _, arg := range args {
+		switch arg {
+		case "--force", "-f":
+			force = true
+		case "--pause":
+			pause = true
+		default:
+			target = arg
+		}
+	}
+
+	code := removeWorkspace(target, force)
+	if pause {
+		fmt.Println()
+		fmt.Print("Press Enter to return to vibeit...")
+		bufio.NewReader(os.Stdin).ReadString('\n')
+	}
+	return code
+}
+
+func removeWorkspace(target string, force bool) int {
+	if target == "" {
+		fmt.Fprintln(os.Stderr, "Usage: vibeit remove [--force] <N|path>")
+		return 1
+	}
+
+	workspaces, err := workspace.Detect()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	mainRepoPath := workspaces[0].Path
+	projectName := filepath.Base(mainRepoPath)
+
+	// Sessions may live on a dedicated tmux server
+	if cfg, err := config.Load(mainRepoPath); err == nil {
+		mux.Configure(cfg.Settings)
+	}
+
+	ws, ok := findWorkspace(workspaces, target)
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Workspace not found: %s\n", target)
+		return 1
+	}
+	if !ws.IsSubWorkspace {
+		fmt.Fprintln(os.Stderr, "The main repository cannot be removed")
+		return 1
+	}
+
+	fmt.Printf("Removing %s (%s)\n", ws.Name, ws.Branch)
+
+	sessionName := mux.SessionName(projectName, ws.Name, ws.Branch)
+	if mux.SessionExists(sessionName) {
+		fmt.Printf("Killing tmux session %s\n", sessionName)
+		if err := mux.KillSession(sessionName); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to kill session: %v\n", err)
+		}
+	}
+
+	if err := workspace_init.Remove(mainRepoPath, ws.Path, force); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if !force {
+			fmt.Fprintln(os.Stderr, "Workspace kept. Fix the teardown or re-run with --force.")
+		}
+		return 1
+	}
+
+	fmt.Printf("Removed %s\n", ws.Path)
+	return 0
+}
+
+// findWorkspace matches a slot number, folder name or path against detected workspaces
+func findWorkspace(workspaces []workspace.Workspace, target string) (workspace.Workspace, bool) {
+	if slot, err := strconv.Atoi(target); err == nil {
+		for _, ws := range workspaces {
+			if ws.IsSubWorkspace && workspace_init.Slot(ws.Path) == slot {
+				return ws, true
+			}
+		}
+		return workspace.Workspace{}, false
+	}
+
+	absTarget, _ := filepath.Abs(target)
+	for _, ws := range workspaces {
+		if ws.Name == target || ws.Path == absTarget {
+			return ws, true
+		}
+	}
+	return workspace.Workspace{}, false
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/emilianotisato/vibeit/internal/workspace"
+	workspace_init "github.com/emilianotisato/vibeit/internal/workspace_init"
+)
+
+// runInit handles `vibeit init [--yes]`: detect the stack and write .vibe/wt.json
+func runInit(args []string) int {
+	yes := false
+	for _, arg := range args {
+		if arg == "--yes" || arg == "-y" {
+			yes = true
+		}
+	}
+
+	repoPath, err := workspace.GetProjectPath()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	config, findings := workspace_init.Propose(repoPath)
+	data, err := workspace_init.FormatConfig(config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Println("Detected:")
+	for _, finding := range findings {
+		fmt.Printf("  • %s\n", finding)
+	}
+	fmt.Println()
+	fmt.Printf("Proposed %s:\n\n", workspace_init.ConfigPath(repoPath))
+	fmt.Println(string(data))
+
+	if !yes {
+		prompt := "Write .vibe/wt.json? [y/N] "
+		if workspace_init.ConfigExists(repoPath) {
+			prompt = "Overwrite existing .vibe/wt.json? [y/N] "
+		}
+		fmt.Print(prompt)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" && answer != "yes" {
+			fmt.Println("Aborted, nothing written.")
+			return 0
+		}
+	}
+
+	path, err := workspace_init.WriteConfig(repoPath, config)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Wrote %s\n", path)
+	return 0
+}
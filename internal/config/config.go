@@ -0,0 +1,289 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Settings are the user-facing vibeit options
+type Settings struct {
+	GitPollInterval int                          `json:"git_poll_interval"`
+	Editor          string                       `json:"editor"`
+	BrowserOpener   string                       `json:"browser_opener"`
+	Theme           string                       `json:"theme"`
+	Mouse           bool                         `json:"mouse"`
+	MdRoots         []string                     `json:"md_roots"`
+	Notes           NotesSettings                `json:"notes"`
+	Tmux            TmuxSettings                 `json:"tmux"`
+	Tools           map[string]string            `json:"tools"`
+	Keys            map[string][]string          `json:"keys"`
+	Themes          map[string]map[string]string `json:"themes"`
+}
+
+// NotesSettings controls how branch notes are seeded and kept
+type NotesSettings struct {
+	Template   string `json:"template"`
+	SessionLog bool   `json:"session_log"`
+}
+
+// TmuxSettings holds the root-table keys vibeit binds in tmux ("off" disables a
+// binding), an optional dedicated server socket and where the dashboard runs
+// when started inside tmux
+type TmuxSettings struct {
+	Socket        string `json:"socket"`
+	Dashboard     string `json:"dashboard"`
+	DetachKey     string `json:"detach_key"`
+	LastWindowKey string `json:"last_window_key"`
+	OverviewKey   string `json:"overview_key"`
+}
+
+const defaultSettings = `{
+    "git_poll_interval": 5,
+    "editor": "nvim",
+    "browser_opener": "omarchy-launch-browser",
+    "theme": "auto",
+    "mouse": true,
+    "md_roots": [],
+    "notes": {
+        "template": "",
+        "session_log": false
+    },
+    "tmux": {
+        "socket": "",
+        "dashboard": "pane",
+        "detach_key": "C-\\",
+        "last_window_key": "C-]",
+        "overview_key": "F9"
+    },
+    "tools": {
+        "claude": "claude",
+        "codex": "codex",
+        "lazygit": "lazygit",
+        "nvim": "nvim"
+    }
+}
+`
+
+// Sources of a configuration value, from lowest to highest precedence
+const (
+	SourceDefault = "default"
+	SourceEnvPfx  = "env "
+)
+
+// envOverrides maps environment variables to the setting they override
+var envOverrides = []struct {
+	key string
+	env string
+}{
+	{"git_poll_interval", "VIBEIT_GIT_POLL_INTERVAL"},
+	{"editor", "VIBEIT_EDITOR"},
+	{"browser_opener", "VIBEIT_BROWSER_OPENER"},
+	{"theme", "VIBEIT_THEME"},
+	{"tmux.socket", "VIBEIT_TMUX_SOCKET"},
+	{"tmux.dashboard", "VIBEIT_TMUX_DASHBOARD"},
+	{"tmux.detach_key", "VIBEIT_TMUX_DETACH_KEY"},
+	{"tmux.last_window_key", "VIBEIT_TMUX_LAST_WINDOW_KEY"},
+	{"tmux.overview_key", "VIBEIT_TMUX_OVERVIEW_KEY"},
+}
+
+// Config is the effective configuration together with where each value came from
+type Config struct {
+	Settings
+
+	values  map[string]any
+	sources map[string]string
+}
+
+// GlobalPath returns ~/.config/vibeit/config.json (honouring XDG_CONFIG_HOME)
+func GlobalPath() string {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "vibeit", "config.json")
+}
+
+// StateDir returns ~/.local/state/vibeit (honouring XDG_STATE_HOME)
+func StateDir() string {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "vibeit")
+}
+
+// DataDir returns ~/.local/share/vibeit (honouring XDG_DATA_HOME)
+func DataDir() string {
+	dir := os.Getenv("XDG_DATA_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return ""
+		}
+		dir = filepath.Join(home, ".local", "share")
+	}
+	return filepath.Join(dir, "vibeit")
+}
+
+// ProjectPath returns .vibe/vibeit.json in the main repo
+func ProjectPath(repoPath string) string {
+	return filepath.Join(repoPath, ".vibe", "vibeit.json")
+}
+
+// Defaults returns the built-in configuration
+func Defaults() Config {
+	c := Config{values: map[string]any{}, sources: map[string]string{}}
+	c.merge([]byte(defaultSettings), SourceDefault)
+	c.decode()
+	return c
+}
+
+// Load merges built-in defaults, the global config, the project config and
+// environment overrides, in that order. repoPath may be empty outside a repo.
+func Load(repoPath string) (Config, error) {
+	c := Defaults()
+
+	paths := []string{GlobalPath()}
+	if repoPath != "" {
+		paths = append(paths, ProjectPath(repoPath))
+	}
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return c, err
+		}
+		if err := c.merge(data, path); err != nil {
+			return c, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+
+	for _, o := range envOverrides {
+		value, ok := os.LookupEnv(o.env)
+		if !ok || strings.TrimSpace(value) == "" {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		if _, isNumber := c.values[o.key].(float64); isNumber {
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				return c, fmt.Errorf("%s: expected a number, got %q", o.env, value)
+			}
+			c.values[o.key] = float64(n)
+		} else {
+			c.values[o.key] = value
+		}
+		c.sources[o.key] = SourceEnvPfx + o.env
+	}
+
+	if err := c.decode(); err != nil {
+		return c, err
+	}
+	return c, nil
+}
+
+// Keys returns every effective setting key in dotted form, sorted
+func (c Config) Keys() []string {
+	keys := make([]string, 0, len(c.values))
+	for k := range c.values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Value returns the effective value of a dotted key such as "tmux.detach_key"
+func (c Config) Value(key string) any {
+	return c.values[key]
+}
+
+// Source returns where a dotted key's value came from
+func (c Config) Source(key string) string {
+	return c.sources[key]
+}
+
+// merge layers a JSON document on top of the current values
+func (c *Config) merge(data []byte, source string) error {
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	return flatten("", doc, func(key string, value any) {
+		c.values[key] = value
+		c.sources[key] = source
+	})
+}
+
+// decode rebuilds Settings from the flattened values
+func (c *Config) decode() error {
+	data, err := json.Marshal(unflatten(c.values))
+	if err != nil {
+		return err
+	}
+	var s Settings
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	c.Settings = s
+	return nil
+}
+
+// flatten calls fn for every leaf of doc with its dotted key. Keys that
+// contain a dot are rejected: unflatten could not tell them from nesting.
+func flatten(prefix string, doc map[string]any, fn func(string, any)) error {
+	for k, v := range doc {
+		key := k
+		if prefix != "" {
+			key = prefix + "." + k
+		}
+		if strings.Contains(k, ".") {
+			return fmt.Errorf("%s: keys can't contain \".\"", key)
+		}
+		if nested, ok := v.(map[string]any); ok {
+			// An empty object sets nothing; as a leaf it would collide with
+			// deeper keys from other layers
+			if err := flatten(key, nested, fn); err != nil {
+				return err
+			}
+			continue
+		}
+		fn(key, v)
+	}
+	return nil
+}
+
+func unflatten(values map[string]any) map[string]any {
+	root := map[string]any{}
+	for key, value := range values {
+		parts := strings.Split(key, ".")
+		node := root
+		for _, part := range parts[:len(parts)-1] {
+			child, ok := node[part].(map[string]any)
+			if !ok {
+				child = map[string]any{}
+				node[part] = child
+			}
+			node = child
+		}
+		node[parts[len(parts)-1]] = value
+	}
+	return root
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMergeRejectsDottedKeys(t *testing.T) {
+	c := Defaults()
+	err := c.merge([]byte(`{"themes": {"my.theme": {"base": "dark"}}}`), "test")
+	if err == nil || !strings.Contains(err.Error(), "themes.my.theme") {
+		t.Fatalf("got %v, want an error naming themes.my.theme", err)
+	}
+}
+
+func TestMergeNestedThemes(t *testing.T) {
+	c := Defaults()
+	if err := c.merge([]byte(`{"themes": {"solarized": {"base": "light", "accent": "#268bd2"}}}`), "test"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.decode(); err != nil {
+		t.Fatal(err)
+	}
+	theme, ok := c.Settings.Themes["solarized"]
+	if !ok || theme["base"] != "light" || theme["accent"] != "#268bd2" {
+		t.Fatalf("got %+v", c.Settings.Themes)
+	}
+}
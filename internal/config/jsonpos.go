@@ -0,0 +1,181 @@
+package config
+
+import (
+	"encoding/json"
+	"strconv"
+	"unicode/utf8"
+)
+
+// nodeKind is the JSON type of a parsed value
+type nodeKind string
+
+const (
+	kindObject nodeKind = "object"
+	kindArray  nodeKind = "array"
+	kindString nodeKind = "string"
+	kindNumber nodeKind = "number"
+	kindBool   nodeKind = "boolean"
+	kindNull   nodeKind = "null"
+)
+
+// node is a JSON value that remembers where it starts in the source,
+// so validation problems can be reported with line and column.
+type node struct {
+	kind   nodeKind
+	offset int
+
+	str   string
+	num   float64
+	isInt bool
+
+	keys       []string
+	keyOffsets []int
+	fields     map[string]*node
+	items      []*node
+}
+
+// parseJSON parses syntactically valid JSON into a node tree.
+// Callers are expected to check syntax with encoding/json first.
+func parseJSON(data []byte) *node {
+	p := &jsonParser{data: data}
+	return p.value()
+}
+
+type jsonParser struct {
+	data []byte
+	pos  int
+}
+
+func (p *jsonParser) skipSpace() {
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case ' ', '\t', '\n', '\r':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *jsonParser) value() *node {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return &node{kind: kindNull, offset: p.pos}
+	}
+
+	start := p.pos
+	switch c := p.data[p.pos]; {
+	case c == '{':
+		return p.object()
+	case c == '[':
+		return p.array()
+	case c == '"':
+		return &node{kind: kindString, offset: start, str: p.string()}
+	case c == 't':
+		p.pos += len("true")
+		return &node{kind: kindBool, offset: start}
+	case c == 'f':
+		p.pos += len("false")
+		return &node{kind: kindBool, offset: start}
+	case c == 'n':
+		p.pos += len("null")
+		return &node{kind: kindNull, offset: start}
+	default:
+		return p.number()
+	}
+}
+
+func (p *jsonParser) object() *node {
+	n := &node{kind: kindObject, offset: p.pos, fields: map[string]*node{}}
+	p.pos++ // {
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) || p.data[p.pos] == '}' {
+			p.pos++
+			return n
+		}
+		if p.data[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+
+		keyOffset := p.pos
+		key := p.string()
+		p.skipSpace()
+		p.pos++ // :
+		n.keys = append(n.keys, key)
+		n.keyOffsets = append(n.keyOffsets, keyOffset)
+		n.fields[key] = p.value()
+	}
+}
+
+func (p *jsonParser) array() *node {
+	n := &node{kind: kindArray, offset: p.pos}
+	p.pos++ // [
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) || p.data[p.pos] == ']' {
+			p.pos++
+			return n
+		}
+		if p.data[p.pos] == ',' {
+			p.pos++
+			continue
+		}
+		n.items = append(n.items, p.value())
+	}
+}
+
+func (p *jsonParser) string() string {
+	start := p.pos
+	p.pos++ // opening quote
+	for p.pos < len(p.data) {
+		switch p.data[p.pos] {
+		case '\\':
+			p.pos += 2
+			continue
+		case '"':
+			p.pos++
+			var s string
+			json.Unmarshal(p.data[start:p.pos], &s)
+			return s
+		}
+		p.pos++
+	}
+	return ""
+}
+
+func (p *jsonParser) number() *node {
+	n := &node{kind: kindNumber, offset: p.pos, isInt: true}
+	start := p.pos
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if c == '.' || c == 'e' || c == 'E' {
+			n.isInt = false
+		} else if !(c == '-' || c == '+' || (c >= '0' && c <= '9')) {
+			break
+		}
+		p.pos++
+	}
+	n.num, _ = strconv.ParseFloat(string(p.data[start:p.pos]), 64)
+	return n
+}
+
+// lineCol converts a byte offset into a 1-based line and column
+func lineCol(data []byte, offset int) (int, int) {
+	if offset > len(data) {
+		offset = len(data)
+	}
+	line, col := 1, 1
+	for i := 0; i < offset; {
+		r, size := utf8.DecodeRune(data[i:])
+		if r == '\n' {
+			line++
+			col = 1
+		} else {
+			col++
+		}
+		i += size
+	}
+	return line, col
+}
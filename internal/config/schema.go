@@ -0,0 +1,264 @@
+package config
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//go:embed schema/*.json
+var schemaFS embed.FS
+
+// schema is the subset of JSON Schema used by the files in schema/
+type schema struct {
+	Ref                  string             `json:"$ref"`
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	AdditionalProperties *additional        `json:"additionalProperties"`
+	PropertyNames        *schema            `json:"propertyNames"`
+	Items                *schema            `json:"items"`
+	Required             []string           `json:"required"`
+	Enum                 []string           `json:"enum"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Pattern              string             `json:"pattern"`
+	Defs                 map[string]*schema `json:"$defs"`
+}
+
+// additional is either `false` or a schema for extra object keys
+type additional struct {
+	allowed bool
+	schema  *schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.allowed = allowed
+		return nil
+	}
+	a.allowed = true
+	return json.Unmarshal(data, &a.schema)
+}
+
+// Schema returns the raw JSON Schema published for a .vibe file, e.g. "wt.json"
+func Schema(name string) ([]byte, error) {
+	return schemaFS.ReadFile("schema/" + strings.TrimSuffix(name, ".json") + ".schema.json")
+}
+
+func loadSchema(name string) (*schema, error) {
+	data, err := Schema(name)
+	if err != nil {
+		return nil, err
+	}
+	var s schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid schema for %s: %w", name, err)
+	}
+	return &s, nil
+}
+
+// schemaIssue is a schema violation at a byte offset
+type schemaIssue struct {
+	offset  int
+	message string
+}
+
+type schemaValidator struct {
+	root   *schema
+	issues []schemaIssue
+}
+
+func validateSchema(root *schema, n *node) []schemaIssue {
+	v := &schemaValidator{root: root}
+	v.validate(root, n, "")
+	return v.issues
+}
+
+func (v *schemaValidator) report(offset int, format string, args ...any) {
+	v.issues = append(v.issues, schemaIssue{offset: offset, message: fmt.Sprintf(format, args...)})
+}
+
+func (v *schemaValidator) resolve(s *schema) *schema {
+	for s != nil && s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, "#/$defs/")
+		s = v.root.Defs[name]
+	}
+	return s
+}
+
+func (v *schemaValidator) validate(s *schema, n *node, path string) {
+	s = v.resolve(s)
+	if s == nil {
+		return
+	}
+
+	label := labelFor(path)
+
+	if s.Type != "" && !matchesType(s.Type, n) {
+		v.report(n.offset, "%s: expected %s, got %s", label, s.Type, describe(n))
+		return
+	}
+
+	if len(s.Enum) > 0 {
+		if n.kind != kindString || !contains(s.Enum, n.str) {
+			v.report(n.offset, "%s: must be one of %s", label, quoteList(s.Enum))
+		}
+	}
+
+	if n.kind == kindString && s.Pattern != "" {
+		if re, err := regexp.Compile(s.Pattern); err == nil && !re.MatchString(n.str) {
+			v.report(n.offset, "%s: %q does not match %s", label, n.str, s.Pattern)
+		}
+	}
+
+	if n.kind == kindNumber {
+		if s.Minimum != nil && n.num < *s.Minimum {
+			v.report(n.offset, "%s: must be >= %v", label, *s.Minimum)
+		}
+		if s.Maximum != nil && n.num > *s.Maximum {
+			v.report(n.offset, "%s: must be <= %v", label, *s.Maximum)
+		}
+	}
+
+	switch n.kind {
+	case kindObject:
+		v.validateObject(s, n, path)
+	case kindArray:
+		for i, item := range n.items {
+			v.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (v *schemaValidator) validateObject(s *schema, n *node, path string) {
+	seen := map[string]bool{}
+	for i, key := range n.keys {
+		child := join(path, key)
+		offset := n.keyOffsets[i]
+
+		if seen[key] {
+			v.report(offset, "%s: duplicate key", child)
+		}
+		seen[key] = true
+
+		if s.PropertyNames != nil {
+			v.validate(s.PropertyNames, &node{kind: kindString, offset: offset, str: key}, child)
+		}
+
+		if prop, ok := s.Properties[key]; ok {
+			v.validate(prop, n.fields[key], child)
+			continue
+		}
+		if s.AdditionalProperties == nil {
+			continue
+		}
+		if !s.AdditionalProperties.allowed {
+			msg := fmt.Sprintf("%s: unknown key", child)
+			if suggestion := closest(key, propertyNames(s)); suggestion != "" {
+				msg += fmt.Sprintf(" (did you mean %q?)", suggestion)
+			}
+			v.report(offset, "%s", msg)
+			continue
+		}
+		v.validate(s.AdditionalProperties.schema, n.fields[key], child)
+	}
+
+	for _, key := range s.Required {
+		if _, ok := n.fields[key]; !ok {
+			v.report(n.offset, "%s: missing required key %q", labelFor(path), key)
+		}
+	}
+}
+
+func labelFor(path string) string {
+	if path == "" {
+		return "document"
+	}
+	return path
+}
+
+func matchesType(typ string, n *node) bool {
+	switch typ {
+	case "integer":
+		return n.kind == kindNumber && n.isInt
+	case "number":
+		return n.kind == kindNumber
+	default:
+		return string(n.kind) == typ
+	}
+}
+
+func describe(n *node) string {
+	if n.kind == kindNumber && !n.isInt {
+		return "a decimal number"
+	}
+	return string(n.kind)
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func propertyNames(s *schema) []string {
+	names := make([]string, 0, len(s.Properties))
+	for name := range s.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// closest returns the candidate within edit distance 2 of key, if any
+func closest(key string, candidates []string) string {
+	best, bestDist := "", 3
+	for _, candidate := range candidates {
+		if d := editDistance(key, candidate); d < bestDist {
+			best, bestDist = candidate, d
+		}
+	}
+	return best
+}
+
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,203 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	workspace_init "github.com/emilianotisato/vibeit/internal/workspace_init"
+)
+
+// Severity of a validation problem
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Problem is a validation finding in a .vibe file
+type Problem struct {
+	File     string
+	Line     int
+	Column   int
+	Severity Severity
+	Message  string
+}
+
+func (p Problem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", p.File, p.Line, p.Column, p.Severity, p.Message)
+}
+
+// HasErrors reports whether any problem is an error rather than a warning
+func HasErrors(problems []Problem) bool {
+	for _, p := range problems {
+		if p.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// vibeFile describes a .vibe/*.json file vibeit reads
+type vibeFile struct {
+	name  string
+	check func(repoPath string, data []byte, root *node) []issue
+}
+
+// issue is a problem found by a file specific check
+type issue struct {
+	offset   int
+	severity Severity
+	message  string
+}
+
+var vibeFiles = []vibeFile{
+	{name: "wt.json", check: checkWorkspaceConfig},
+	{name: "vibeit.json"},
+}
+
+// Files returns the names of the .vibe files that have a published schema
+func Files() []string {
+	names := make([]string, len(vibeFiles))
+	for i, f := range vibeFiles {
+		names[i] = f.name
+	}
+	return names
+}
+
+// Validate checks every .vibe file present in the repository
+func Validate(repoPath string) []Problem {
+	var problems []Problem
+	for _, f := range vibeFiles {
+		path := filepath.Join(repoPath, ".vibe", f.name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		problems = append(problems, validateFile(repoPath, f)...)
+	}
+	return problems
+}
+
+// ValidateFile checks a single .vibe file such as "wt.json"
+func ValidateFile(repoPath, name string) []Problem {
+	for _, f := range vibeFiles {
+		if f.name == name {
+			return validateFile(repoPath, f)
+		}
+	}
+	return []Problem{{File: ".vibe/" + name, Line: 1, Column: 1, Severity: SeverityError, Message: "unknown vibeit file"}}
+}
+
+// ValidateGlobal checks ~/.config/vibeit/config.json when it exists
+func ValidateGlobal() []Problem {
+	path := GlobalPath()
+	if _, err := os.Stat(path); err != nil {
+		return nil
+	}
+	return validatePath("", path, path, vibeFile{name: "vibeit.json"})
+}
+
+func validateFile(repoPath string, f vibeFile) []Problem {
+	display := filepath.Join(".vibe", f.name)
+	return validatePath(repoPath, display, filepath.Join(repoPath, display), f)
+}
+
+func validatePath(repoPath, display, path string, f vibeFile) []Problem {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return []Problem{{File: display, Line: 1, Column: 1, Severity: SeverityError, Message: err.Error()}}
+	}
+
+	problem := func(offset int, severity Severity, message string) Problem {
+		line, col := lineCol(data, offset)
+		return Problem{File: display, Line: line, Column: col, Severity: severity, Message: message}
+	}
+
+	// Syntax errors come straight from encoding/json
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			// Offset counts the offending byte; point at it, not past it
+			offset := int(syntaxErr.Offset)
+			if offset > 0 && offset <= len(data) && syntaxErr.Error() != "unexpected end of JSON input" {
+				offset--
+			}
+			return []Problem{problem(offset, SeverityError, syntaxErr.Error())}
+		}
+		return []Problem{problem(0, SeverityError, err.Error())}
+	}
+
+	root := parseJSON(data)
+
+	s, err := loadSchema(f.name)
+	if err != nil {
+		return []Problem{problem(0, SeverityError, err.Error())}
+	}
+
+	var problems []Problem
+	for _, si := range validateSchema(s, root) {
+		problems = append(problems, problem(si.offset, SeverityError, si.message))
+	}
+	if len(problems) > 0 {
+		return problems
+	}
+
+	if f.check != nil {
+		for _, is := range f.check(repoPath, data, root) {
+			problems = append(problems, problem(is.offset, is.severity, is.message))
+		}
+	}
+	return problems
+}
+
+// checkWorkspaceConfig performs wt.json checks that a schema can't express
+func checkWorkspaceConfig(repoPath string, data []byte, root *node) []issue {
+	var issues []issue
+
+	for _, item := range arrayField(root, "copy") {
+		if _, err := os.Stat(filepath.Join(repoPath, item.str)); err != nil {
+			issues = append(issues, issue{item.offset, SeverityWarning, fmt.Sprintf("copy: %q does not exist in the main repo", item.str)})
+		}
+	}
+
+	for _, item := range arrayField(root, "templates") {
+		if _, err := os.Stat(filepath.Join(repoPath, item.str)); err != nil {
+			issues = append(issues, issue{item.offset, SeverityError, fmt.Sprintf("templates: %q does not exist in the main repo", item.str)})
+		}
+	}
+
+	var wt workspace_init.Config
+	if err := json.Unmarshal(data, &wt); err != nil {
+		return append(issues, issue{0, SeverityError, err.Error()})
+	}
+
+	if resources, ok := root.fields["resources"]; ok {
+		for i, name := range resources.keys {
+			res := wt.Resources[name]
+			config := workspace_init.Config{Resources: map[string]workspace_init.Resource{name: res}}
+			for _, slot := range []int{0, 9} {
+				if _, err := config.Allocate(slot); err != nil {
+					issues = append(issues, issue{resources.keyOffsets[i], SeverityError, fmt.Sprintf("%v (slot %d)", err, slot)})
+					break
+				}
+			}
+		}
+		if err := wt.CheckOverlaps(); err != nil {
+			issues = append(issues, issue{resources.offset, SeverityError, err.Error()})
+		}
+	}
+
+	return issues
+}
+
+func arrayField(n *node, key string) []*node {
+	field, ok := n.fields[key]
+	if !ok {
+		return nil
+	}
+	return field.items
+}
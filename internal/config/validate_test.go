@@ -0,0 +1,151 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestValidateFileReportsPositions(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		want    []Problem
+	}{
+		{
+			name:    "valid",
+			file:    "wt.json",
+			content: `{"before": [], "resources": {"APP_PORT": {"type": "port", "base": 3000}}}`,
+		},
+		{
+			name: "wrong type",
+			file: "wt.json",
+			content: `{
+  "before": "npm install"
+}`,
+			want: []Problem{{Line: 2, Column: 13, Severity: SeverityError, Message: "before: expected array, got string"}},
+		},
+		{
+			name: "enum",
+			file: "wt.json",
+			content: `{
+  "resources": {
+    "APP_PORT": {"type": "socket", "base": 3000}
+  }
+}`,
+			want: []Problem{{Line: 3, Column: 26, Severity: SeverityError, Message: `resources.APP_PORT.type: must be one of "port", "number", "name"`}},
+		},
+		{
+			name: "required",
+			file: "wt.json",
+			content: `{
+  "resources": {
+    "APP_PORT": {"base": 3000}
+  }
+}`,
+			want: []Problem{{Line: 3, Column: 17, Severity: SeverityError, Message: `resources.APP_PORT: missing required key "type"`}},
+		},
+		{
+			name: "unknown key",
+			file: "wt.json",
+			content: `{
+  "befor": []
+}`,
+			want: []Problem{{Line: 2, Column: 3, Severity: SeverityError, Message: `befor: unknown key (did you mean "before"?)`}},
+		},
+		{
+			name:    "unknown key after multibyte text",
+			file:    "wt.json",
+			content: `{"copy": ["ñandú"], "befor": []}`,
+			want:    []Problem{{Line: 1, Column: 21, Severity: SeverityError, Message: `befor: unknown key (did you mean "before"?)`}},
+		},
+		{
+			name: "nested minimum",
+			file: "wt.json",
+			content: `{
+  "resources": {
+    "APP_PORT": {"type": "port", "step": 0}
+  }
+}`,
+			want: []Problem{{Line: 3, Column: 42, Severity: SeverityError, Message: "resources.APP_PORT.step: must be >= 1"}},
+		},
+		{
+			name: "nested array item",
+			file: "wt.json",
+			content: `{
+  "hooks": {"git_status_changed": ["ok", 1]}
+}`,
+			want: []Problem{{Line: 2, Column: 42, Severity: SeverityError, Message: "hooks.git_status_changed[1]: expected string, got number"}},
+		},
+		{
+			name: "overlapping resources",
+			file: "wt.json",
+			content: `{
+  "resources": {
+    "APP_PORT": {"type": "port", "base": 3000},
+    "VITE_PORT": {"type": "port", "base": 3001}
+  }
+}`,
+			want: []Problem{{Line: 2, Column: 16, Severity: SeverityError, Message: "resources APP_PORT and VITE_PORT overlap: both use 3001 (slots 1 and 0)"}},
+		},
+		{
+			name:    "missing copy path",
+			file:    "wt.json",
+			content: `{"copy": ["missing.env"]}`,
+			want:    []Problem{{Line: 1, Column: 11, Severity: SeverityWarning, Message: `copy: "missing.env" does not exist in the main repo`}},
+		},
+		{
+			name:    "dotted theme name",
+			file:    "vibeit.json",
+			content: `{"themes": {"my.theme": {"base": "dark"}}}`,
+			want:    []Problem{{Line: 1, Column: 13, Severity: SeverityError, Message: `themes.my.theme: "my.theme" does not match ^[^.]+$`}},
+		},
+		{
+			name: "settings enum",
+			file: "vibeit.json",
+			content: `{
+  "tmux": {"dashboard": "tab"}
+}`,
+			want: []Problem{{Line: 2, Column: 25, Severity: SeverityError, Message: `tmux.dashboard: must be one of "pane", "window", "popup"`}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := t.TempDir()
+			path := filepath.Join(repo, ".vibe", tt.file)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got := ValidateFile(repo, tt.file)
+			for i := range tt.want {
+				tt.want[i].File = filepath.Join(".vibe", tt.file)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got  %v\nwant %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFileSyntaxError(t *testing.T) {
+	repo := t.TempDir()
+	path := filepath.Join(repo, ".vibe", "wt.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{\n  \"copy\": [\".env\",]\n}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ValidateFile(repo, "wt.json")
+	if len(got) != 1 || got[0].Severity != SeverityError || got[0].Line != 2 || got[0].Column != 19 {
+		t.Fatalf("got %v, want one error at 2:19", got)
+	}
+}
@@ -6,6 +6,10 @@ import (
 	"os/exec"
 	"regexp"
 	"strings"
+
+	"github.com/emilianotisato/vibeit/internal/config"
+	"github.com/emilianotisato/vibeit/internal/mux"
+	"github.com/emilianotisato/vibeit/internal/workspace"
 )
 
 type Dependency struct {
@@ -17,7 +21,7 @@ type Dependency struct {
 
 var dependencies = []Dependency{
 	{Name: "git", Command: "git", Required: true, MinVer: "2.0"},
-	{Name: "tmux", Command: "tmux", Required: true, MinVer: "3.0"},
+	{Name: "tmux", Command: "tmux", Required: true, MinVer: "3.2"},
 	{Name: "neovim", Command: "nvim", Required: true, MinVer: "0.9"},
 	{Name: "lazygit", Command: "lazygit", Required: false, MinVer: "0.40"},
 }
@@ -27,6 +31,12 @@ func Run() int {
 	fmt.Println("=============")
 	fmt.Println()
 
+	// Check the tmux server vibeit actually uses
+	projectPath, _ := workspace.GetProjectPath()
+	if cfg, err := config.Load(projectPath); err == nil {
+		mux.Configure(cfg.Settings)
+	}
+
 	allOk := true
 
 	for _, dep := range dependencies {
@@ -38,14 +48,20 @@ func Run() int {
 	}
 
 	printTmuxDetachStatus()
+	configOk := printConfigStatus()
 
 	fmt.Println()
 
-	if allOk {
+	if allOk && configOk {
 		fmt.Println("All required dependencies are installed!")
 		return 0
 	}
 
+	if allOk {
+		fmt.Println("Project config has errors. Run 'vibeit config validate' for details.")
+		return 1
+	}
+
 	fmt.Println("Some required dependencies are missing. Please install them:")
 	fmt.Println()
 	printInstallInstructions()
@@ -170,6 +186,14 @@ func printTmuxDetachStatus() {
 	fmt.Println()
 	fmt.Println("Tmux keybinding check:")
 
+	if socket := mux.Socket(); socket != "" {
+		fmt.Printf("  ✓ vibeit runs its own tmux server (tmux -L %s); ~/.tmux.conf is not loaded or changed\n", socket)
+		fmt.Printf("    Generated config: %s\n", mux.ServerConfigPath())
+		fmt.Printf("    Your additions:   %s\n", mux.UserServerConfigPath())
+		fmt.Printf("  Verify: tmux -L %s list-keys -T root | grep -F 'C-\\\\'\n", socket)
+		return
+	}
+
 	ok, source := checkTmuxDetachBinding()
 	if ok {
 		fmt.Printf("  ✓ Ctrl+\\\\ detach binding found (%s)\n", source)
@@ -184,7 +208,7 @@ func printTmuxDetachStatus() {
 
 func checkTmuxDetachBinding() (bool, string) {
 	// First check the live tmux root table (if a server is running).
-	cmd := exec.Command("tmux", "list-keys", "-T", "root")
+	cmd := mux.Command("list-keys", "-T", "root")
 	out, err := cmd.Output()
 	if err == nil {
 		if hasDetachBinding(string(out)) {
@@ -223,3 +247,29 @@ func hasDetachBinding(text string) bool {
 	}
 	return false
 }
+
+func printConfigStatus() bool {
+	repoPath, err := workspace.GetProjectPath()
+	if err != nil {
+		// Not inside a repository, nothing to validate
+		return true
+	}
+
+	fmt.Println()
+	fmt.Println("Project config check:")
+
+	problems := config.Validate(repoPath)
+	if len(problems) == 0 {
+		fmt.Println("  ✓ .vibe files are valid")
+		return true
+	}
+
+	for _, p := range problems {
+		if p.Severity == config.SeverityError {
+			fmt.Printf("  ✗ %s\n", p)
+		} else {
+			fmt.Printf("  ⚠ %s\n", p)
+		}
+	}
+	return !config.HasErrors(problems)
+}
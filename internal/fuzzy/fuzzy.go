@@ -0,0 +1,107 @@
+// Package fuzzy implements the subsequence matcher used by vibeit pickers.
+package fuzzy
+
+import (
+	"sort"
+	"unicode"
+)
+
+const (
+	scoreMatch       = 16
+	bonusConsecutive = 8
+	bonusWordStart   = 10
+	penaltyGap       = 1
+	maxStartPenalty  = 10
+)
+
+// Match is an item that matched a pattern
+type Match struct {
+	Index     int   // index into the items passed to Filter
+	Score     int   // higher is better
+	Positions []int // rune offsets of the matched characters
+}
+
+// Score reports whether every rune of pattern appears in text in order,
+// ignoring case. Consecutive runs, word starts and early matches score higher.
+func Score(pattern, text string) (int, []int, bool) {
+	pat := []rune(pattern)
+	if len(pat) == 0 {
+		return 0, nil, true
+	}
+	runes := []rune(text)
+
+	// Find the first end position by scanning forward, then walk back from it
+	// to the tightest start so "ab" in "a_xab" matches the trailing "ab".
+	pi, end := 0, -1
+	for i, r := range runes {
+		if equalFold(r, pat[pi]) {
+			pi++
+			if pi == len(pat) {
+				end = i
+				break
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	positions := make([]int, len(pat))
+	pi = len(pat) - 1
+	for i := end; i >= 0 && pi >= 0; i-- {
+		if equalFold(runes[i], pat[pi]) {
+			positions[pi] = i
+			pi--
+		}
+	}
+
+	score := 0
+	for i, pos := range positions {
+		score += scoreMatch
+		if isWordStart(runes, pos) {
+			score += bonusWordStart
+		}
+		if i > 0 {
+			if pos == positions[i-1]+1 {
+				score += bonusConsecutive
+			} else {
+				score -= (pos - positions[i-1] - 1) * penaltyGap
+			}
+		}
+	}
+	score -= min(positions[0], maxStartPenalty)
+
+	return score, positions, true
+}
+
+// Filter returns the items matching pattern, best first. Ties keep the
+// original order, so an empty pattern returns every item unchanged.
+func Filter(pattern string, items []string) []Match {
+	var matches []Match
+	for i, item := range items {
+		if score, positions, ok := Score(pattern, item); ok {
+			matches = append(matches, Match{Index: i, Score: score, Positions: positions})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		return matches[a].Score > matches[b].Score
+	})
+	return matches
+}
+
+func equalFold(a, b rune) bool {
+	return a == b || unicode.ToLower(a) == unicode.ToLower(b)
+}
+
+// isWordStart reports whether the rune at i begins a word: the first rune,
+// one after a separator, or an uppercase rune after a lowercase one.
+func isWordStart(runes []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := runes[i-1], runes[i]
+	if !unicode.IsLetter(prev) && !unicode.IsDigit(prev) {
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(cur)
+}
@@ -0,0 +1,112 @@
+package fuzzy
+
+import (
+	"slices"
+	"testing"
+)
+
+// filtered returns the items Filter kept, in result order
+func filtered(pattern string, items []string) []string {
+	var out []string
+	for _, m := range Filter(pattern, items) {
+		out = append(out, items[m.Index])
+	}
+	return out
+}
+
+func TestFilterOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		items   []string
+		want    []string
+	}{
+		{
+			name:    "prefix, word start, substring, scattered",
+			pattern: "note",
+			items:   []string{"nxoxtxe", "keynote", "open notes", "notes picker"},
+			want:    []string{"notes picker", "open notes", "keynote", "nxoxtxe"},
+		},
+		{
+			name:    "camel case word starts",
+			pattern: "gs",
+			items:   []string{"gas", "gitStatus"},
+			want:    []string{"gitStatus", "gas"},
+		},
+		{
+			name:    "separators start words",
+			pattern: "ns",
+			items:   []string{"lines", "new_session"},
+			want:    []string{"new_session", "lines"},
+		},
+		{
+			name:    "ties keep input order",
+			pattern: "tab",
+			items:   []string{"tab two", "tab one"},
+			want:    []string{"tab two", "tab one"},
+		},
+		{
+			name:    "empty pattern keeps everything",
+			pattern: "",
+			items:   []string{"b", "a", "c"},
+			want:    []string{"b", "a", "c"},
+		},
+		{
+			name:    "non-matches are dropped",
+			pattern: "ton",
+			items:   []string{"notes", "button", "tonic"},
+			want:    []string{"tonic", "button"},
+		},
+		{
+			name:    "case insensitive",
+			pattern: "NOTES",
+			items:   []string{"Open Notes", "todos"},
+			want:    []string{"Open Notes"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := filtered(tt.pattern, tt.items); !slices.Equal(got, tt.want) {
+				t.Errorf("Filter(%q) = %q, want %q", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestScorePositions(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		want          []int
+	}{
+		{pattern: "ab", text: "a_xab", want: []int{3, 4}},
+		{pattern: "on", text: "open notes", want: []int{0, 3}},
+		{pattern: "ÚL", text: "año último", want: []int{4, 5}},
+		{pattern: "日本", text: "docs/日本語.md", want: []int{5, 6}},
+		{pattern: "", text: "anything", want: nil},
+	}
+
+	for _, tt := range tests {
+		_, positions, ok := Score(tt.pattern, tt.text)
+		if !ok {
+			t.Errorf("Score(%q, %q) did not match", tt.pattern, tt.text)
+			continue
+		}
+		if !slices.Equal(positions, tt.want) {
+			t.Errorf("Score(%q, %q) positions = %v, want %v", tt.pattern, tt.text, positions, tt.want)
+		}
+	}
+}
+
+func TestScoreNoMatch(t *testing.T) {
+	for _, tt := range []struct{ pattern, text string }{
+		{"xyz", "notes"},
+		{"seton", "notes"},
+		{"notess", "notes"},
+		{"u", "último"},
+	} {
+		if _, _, ok := Score(tt.pattern, tt.text); ok {
+			t.Errorf("Score(%q, %q) matched", tt.pattern, tt.text)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package hooks
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// detachedRun is the job RunDetached hands to `vibeit run-hooks`
+type detachedRun struct {
+	Commands []string `json:"commands"`
+	Payload  Payload  `json:"payload"`
+}
+
+// RunDetached runs an event's hooks in a separate `exe run-hooks` process in
+// its own session, so they outlive a vibeit that is about to exit
+func RunDetached(exe string, commands []string, payload Payload) error {
+	if len(commands) == 0 {
+		return nil
+	}
+	data, err := json.Marshal(detachedRun{Commands: commands, Payload: payload})
+	if err != nil {
+		return err
+	}
+
+	// A file, unlike a pipe, stays readable after this process is gone
+	job, err := os.CreateTemp("", "vibeit-hooks-*.json")
+	if err != nil {
+		return err
+	}
+	defer job.Close()
+	os.Remove(job.Name())
+	if _, err := job.Write(data); err != nil {
+		return err
+	}
+	if _, err := job.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	cmd := exec.Command(exe, "run-hooks")
+	cmd.Dir = payload.Path
+	cmd.Stdin = job
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	return cmd.Process.Release()
+}
+
+// RunFromReader runs a job written by RunDetached
+func RunFromReader(r io.Reader) error {
+	var job detachedRun
+	if err := json.NewDecoder(r).Decode(&job); err != nil {
+		return err
+	}
+	return Run(job.Commands, job.Payload)
+}
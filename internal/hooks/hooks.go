@@ -0,0 +1,143 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Event names a lifecycle hook configured under "hooks" in .vibe/wt.json
+type Event string
+
+const (
+	WorkspaceActivated Event = "workspace_activated"
+	SessionCreated     Event = "session_created"
+	AgentStarted       Event = "agent_started"
+	GitStatusChanged   Event = "git_status_changed"
+)
+
+// Events lists every supported hook event
+var Events = []Event{
+	WorkspaceActivated,
+	SessionCreated,
+	AgentStarted,
+	GitStatusChanged,
+}
+
+// Git status changes reported in Payload.Change
+const (
+	ChangeDirty  = "dirty"
+	ChangeClean  = "clean"
+	ChangeCommit = "commit"
+	ChangeHead   = "head"
+)
+
+const hookTimeout = 30 * time.Second
+
+// hookWaitDelay bounds how long a finished or timed-out hook may keep its
+// stdin copy open, e.g. through a process it started in the background
+const hookWaitDelay = 2 * time.Second
+
+// hookOutputTail is how much of a hook's output is read to report a failure
+const hookOutputTail = 4096
+
+// Payload is the JSON document written to a hook's stdin
+type Payload struct {
+	Event     Event     `json:"event"`
+	Time      time.Time `json:"time"`
+	Project   string    `json:"project"`
+	Workspace string    `json:"workspace"`
+	Path      string    `json:"path"`
+	Branch    string    `json:"branch"`
+	Session   string    `json:"session,omitempty"`
+	Tab       string    `json:"tab,omitempty"`
+	Agent     string    `json:"agent,omitempty"`
+	Change    string    `json:"change,omitempty"`
+	Commit    string    `json:"commit,omitempty"`
+	Subject   string    `json:"subject,omitempty"`
+}
+
+// Run executes hook commands in order inside the workspace, feeding each one the payload on stdin.
+// Output is captured and included in the error when a command fails.
+func Run(commands []string, payload Payload) error {
+	if len(commands) == 0 {
+		return nil
+	}
+
+	if payload.Time.IsZero() {
+		payload.Time = time.Now()
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	for _, cmdStr := range commands {
+		if err := runHook(cmdStr, payload, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runHook(cmdStr string, payload Payload, data []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", cmdStr)
+	cmd.Dir = payload.Path
+	cmd.Env = append(os.Environ(), "VIBEIT_EVENT="+string(payload.Event))
+	cmd.Stdin = bytes.NewReader(data)
+	cmd.WaitDelay = hookWaitDelay
+
+	// Output goes to a file, not a pipe: a process the hook backgrounds
+	// (`npm run dev &`) inherits it and would otherwise keep Wait blocked
+	output, err := os.CreateTemp("", "vibeit-hook-*.log")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(output.Name())
+	defer output.Close()
+	cmd.Stdout = output
+	cmd.Stderr = output
+
+	err = cmd.Run()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("%s hook '%s' timed out after %s", payload.Event, cmdStr, hookTimeout)
+	}
+	if errors.Is(err, exec.ErrWaitDelay) {
+		// The hook itself succeeded; only a background process kept stdin open
+		err = nil
+	}
+	if err != nil {
+		msg := strings.TrimSpace(outputTail(output))
+		if msg == "" {
+			return fmt.Errorf("%s hook '%s' failed: %w", payload.Event, cmdStr, err)
+		}
+		return fmt.Errorf("%s hook '%s' failed: %s", payload.Event, cmdStr, lastLine(msg))
+	}
+	return nil
+}
+
+// outputTail returns the last hookOutputTail bytes a hook wrote
+func outputTail(f *os.File) string {
+	info, err := f.Stat()
+	if err != nil {
+		return ""
+	}
+	offset := max(info.Size()-hookOutputTail, 0)
+	buf := make([]byte, info.Size()-offset)
+	n, _ := f.ReadAt(buf, offset)
+	return string(buf[:n])
+}
+
+func lastLine(text string) string {
+	lines := strings.Split(text, "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
@@ -0,0 +1,128 @@
+package mux
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Where the dashboard runs when vibeit starts inside tmux (tmux.dashboard)
+const (
+	DashboardPane   = "pane"
+	DashboardWindow = "window"
+	DashboardPopup  = "popup"
+)
+
+// dashboardEnv marks a vibeit that tmux started as the dashboard, so it
+// doesn't move itself again
+const dashboardEnv = "VIBEIT_DASHBOARD"
+
+const dashboardWindowName = "vibeit"
+
+// InsideTmux reports whether vibeit runs inside a client of the tmux server
+// its sessions live on, where attaching would nest instead of switching
+func InsideTmux() bool {
+	value := os.Getenv("TMUX")
+	if value == "" {
+		return false
+	}
+	if Socket() == "" {
+		// Without -L, tmux commands go to the server in $TMUX anyway
+		return true
+	}
+	socketPath, _, _ := strings.Cut(value, ",")
+	return filepath.Base(socketPath) == Socket()
+}
+
+// nestedInForeignTmux reports whether vibeit runs inside some other tmux
+// server than its dedicated one; attaching then means a nested client
+func nestedInForeignTmux() bool {
+	return os.Getenv("TMUX") != "" && !InsideTmux()
+}
+
+// attachScript moves the current terminal to a session: switch-client inside
+// tmux, attach everywhere else
+func attachScript(sessionName string) string {
+	if InsideTmux() {
+		return "tmux switch-client -t " + shellQuote(sessionName) + "; "
+	}
+	return "tmux attach -t " + shellQuote(sessionName) + "; "
+}
+
+// OpenDashboard moves a vibeit started inside tmux to a dedicated window or
+// a popup, per placement. It reports whether the dashboard now runs there,
+// in which case this process has nothing left to do.
+func OpenDashboard(placement, workDir string) (bool, error) {
+	if !InsideTmux() || os.Getenv(dashboardEnv) != "" {
+		return false, nil
+	}
+
+	switch placement {
+	case DashboardWindow:
+		// ":=" matches the window name exactly, in the current session only
+		script := "tmux select-window -t " + shellQuote(":="+dashboardWindowName) + " 2>/dev/null || " +
+			"tmux new-window -n " + shellQuote(dashboardWindowName) + " -c " + shellQuote(workDir) + " " + shellQuote(dashboardCommand(DashboardWindow))
+		return true, scriptCmd(script, workDir).Run()
+	case DashboardPopup:
+		return true, Command(popupArgs(workDir)...).Run()
+	default:
+		return false, nil
+	}
+}
+
+// EnterDashboard points the detach key back at this dashboard while it runs
+// inside tmux: its pane for the pane and window placements, a fresh popup for
+// the popup placement
+func EnterDashboard(workDir string) {
+	if !InsideTmux() {
+		return
+	}
+	if os.Getenv(dashboardEnv) == DashboardPopup {
+		current.dashboardPopup = true
+		current.dashboardReturn = "display-popup " + strings.Join(quoteAll(popupArgs(workDir)[1:]), " ")
+		return
+	}
+	if pane := os.Getenv("TMUX_PANE"); pane != "" {
+		current.dashboardReturn = "switch-client -t " + shellQuote(pane)
+	}
+}
+
+// LeaveDashboard restores the plain detach binding when a dashboard pane goes
+// away. A popup dashboard keeps its binding: the key reopens it.
+func LeaveDashboard() {
+	if current.dashboardReturn == "" || current.dashboardPopup {
+		return
+	}
+	current.dashboardReturn = ""
+	if key := tmuxDetachKey(); key != "" {
+		_ = Command("bind-key", "-n", key, "detach-client").Run()
+	}
+}
+
+// QuitAfterAttach reports whether the dashboard should exit once it moved the
+// client into a session, which closes its popup
+func QuitAfterAttach() bool {
+	return current.dashboardPopup
+}
+
+// popupArgs returns the display-popup command that runs the dashboard
+func popupArgs(workDir string) []string {
+	return []string{"display-popup", "-E", "-w", "90%", "-h", "90%", "-d", workDir, dashboardCommand(DashboardPopup)}
+}
+
+// dashboardCommand is the shell command tmux runs to start the dashboard
+func dashboardCommand(placement string) string {
+	exePath, err := os.Executable()
+	if err != nil || exePath == "" {
+		exePath = "vibeit"
+	}
+	return "env " + dashboardEnv + "=" + placement + " " + shellQuote(exePath)
+}
+
+func quoteAll(values []string) []string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = shellQuote(value)
+	}
+	return quoted
+}
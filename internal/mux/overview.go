@@ -2,7 +2,6 @@ package mux
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 )
 
@@ -195,17 +194,17 @@ func tmuxPaneOption(paneID, option string) string {
 }
 
 func tmuxPaneExists(paneID string) bool {
-	_, err := exec.Command("tmux", "display-message", "-p", "-t", paneID, "#{pane_id}").Output()
+	_, err := Command("display-message", "-p", "-t", paneID, "#{pane_id}").Output()
 	return err == nil
 }
 
 func tmuxWindowExists(winID string) bool {
-	_, err := exec.Command("tmux", "display-message", "-p", "-t", winID, "#{window_id}").Output()
+	_, err := Command("display-message", "-p", "-t", winID, "#{window_id}").Output()
 	return err == nil
 }
 
 func tmuxOutput(args ...string) (string, error) {
-	cmd := exec.Command("tmux", args...)
+	cmd := Command(args...)
 	out, err := cmd.Output()
 	if err != nil {
 		return "", err
@@ -214,6 +213,6 @@ func tmuxOutput(args ...string) (string, error) {
 }
 
 func tmuxRun(args ...string) error {
-	cmd := exec.Command("tmux", args...)
+	cmd := Command(args...)
 	return cmd.Run()
 }
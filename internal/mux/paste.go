@@ -0,0 +1,25 @@
+package mux
+
+import (
+	"fmt"
+	"strings"
+)
+
+const pasteBuffer = "vibeit-send"
+
+// PasteToTab pastes text into the active pane of a session's tab. It uses
+// bracketed paste and doesn't press Enter, so a multi-line prompt arrives in
+// one piece and can be reviewed before it is sent.
+func PasteToTab(sessionName, tabName, text string) error {
+	load := Command("load-buffer", "-b", pasteBuffer, "-")
+	load.Stdin = strings.NewReader(text)
+	if out, err := load.CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux load-buffer: %s", strings.TrimSpace(string(out)))
+	}
+
+	target := fmt.Sprintf("%s:%s", sessionName, tabName)
+	if out, err := Command("paste-buffer", "-d", "-p", "-b", pasteBuffer, "-t", target).CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux paste-buffer: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
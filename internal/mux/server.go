@@ -0,0 +1,89 @@
+package mux
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/emilianotisato/vibeit/internal/config"
+)
+
+// Socket returns the dedicated tmux socket name, or "" for the user's default server
+func Socket() string {
+	return strings.TrimSpace(current.settings.Tmux.Socket)
+}
+
+// ServerConfigPath returns the generated config of the dedicated tmux server
+func ServerConfigPath() string {
+	return filepath.Join(config.StateDir(), fmt.Sprintf("tmux-%s.conf", Socket()))
+}
+
+// UserServerConfigPath returns the optional file the generated config sources,
+// for settings of the dedicated server such as mouse or status line
+func UserServerConfigPath() string {
+	return filepath.Join(filepath.Dir(config.GlobalPath()), "tmux.conf")
+}
+
+// serverArgs returns the global tmux flags that select vibeit's server
+func serverArgs() []string {
+	if Socket() == "" {
+		return nil
+	}
+	args := []string{"-L", Socket()}
+	if current.serverConfigOK {
+		args = append(args, "-f", ServerConfigPath())
+	}
+	return args
+}
+
+// Command returns a tmux command that runs against vibeit's server
+func Command(args ...string) *exec.Cmd {
+	return exec.Command("tmux", append(serverArgs(), args...)...)
+}
+
+// scriptCmd runs a tmux shell script in workDir. With a dedicated socket, a
+// tmux function routes every call in the script to vibeit's server.
+func scriptCmd(script, workDir string) *exec.Cmd {
+	if args := serverArgs(); len(args) > 0 {
+		script = fmt.Sprintf(`tmux() { command tmux %s "$@"; }; `, strings.Join(quoteAll(args), " ")) + script
+	}
+	cmd := exec.Command("sh", "-c", script)
+	cmd.Dir = workDir
+	if nestedInForeignTmux() {
+		// tmux refuses to attach from inside another server unless $TMUX is unset
+		cmd.Env = append(os.Environ(), "TMUX=")
+	}
+	return cmd
+}
+
+// writeServerConfig generates the config the dedicated server starts with:
+// vibeit's key bindings plus the user's own additions, never ~/.tmux.conf.
+func writeServerConfig() error {
+	var b strings.Builder
+	b.WriteString("# Generated by vibeit for its dedicated tmux server; changes are overwritten.\n")
+	fmt.Fprintf(&b, "# Put your own settings in %s\n\n", UserServerConfigPath())
+
+	if key := tmuxDetachKey(); key != "" {
+		fmt.Fprintf(&b, "bind-key -n %s detach-client\n", tmuxConfQuote(key))
+	}
+	if key := tmuxLastWindowKey(); key != "" {
+		fmt.Fprintf(&b, "bind-key -n %s last-window\n", tmuxConfQuote(key))
+	}
+	if key := tmuxOverviewKey(); key != "" {
+		fmt.Fprintf(&b, "bind-key -n %s run-shell %s\n", tmuxConfQuote(key), tmuxConfQuote(tmuxOverviewCmd()))
+	}
+	fmt.Fprintf(&b, "\nsource-file -q %s\n", tmuxConfQuote(UserServerConfigPath()))
+
+	if err := os.MkdirAll(filepath.Dir(ServerConfigPath()), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(ServerConfigPath(), []byte(b.String()), 0o644)
+}
+
+// tmuxConfQuote quotes a value for a tmux config file, where single-quoted
+// strings are taken literally
+func tmuxConfQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
@@ -0,0 +1,83 @@
+package mux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emilianotisato/vibeit/internal/config"
+)
+
+// Window is a tmux window together with the commands running in its panes
+type Window struct {
+	Index    int
+	Name     string
+	Commands []string
+}
+
+// ListWindows returns the windows of a session and what each pane is running
+func ListWindows(sessionName string) ([]Window, error) {
+	out, err := tmuxOutput("list-panes", "-s", "-t", sessionName, "-F", "#{window_index}\t#{window_name}\t#{pane_current_command}")
+	if err != nil {
+		return nil, err
+	}
+
+	var windows []Window
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		index, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		if n := len(windows); n == 0 || windows[n-1].Index != index {
+			windows = append(windows, Window{Index: index, Name: fields[1]})
+		}
+		windows[len(windows)-1].Commands = append(windows[len(windows)-1].Commands, fields[2])
+	}
+	return windows, nil
+}
+
+// SnapshotDir returns where session snapshots are written
+func SnapshotDir() string {
+	return filepath.Join(config.StateDir(), "snapshots")
+}
+
+// SnapshotSession captures the full scrollback of every pane in a session to
+// {SnapshotDir}/{session}-{timestamp}/ and returns that directory.
+func SnapshotSession(sessionName string) (string, error) {
+	if config.StateDir() == "" {
+		return "", fmt.Errorf("cannot determine state directory")
+	}
+
+	panes, err := tmuxOutput("list-panes", "-s", "-t", sessionName, "-F", "#{pane_id}\t#{window_index}-#{window_name}.#{pane_index}")
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(SnapshotDir(), sessionName+"-"+time.Now().Format("20060102-150405"))
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+
+	for _, line := range strings.Split(panes, "\n") {
+		paneID, label, ok := strings.Cut(line, "\t")
+		if !ok {
+			continue
+		}
+		content, err := tmuxOutput("capture-pane", "-p", "-J", "-S", "-", "-t", paneID)
+		if err != nil {
+			return dir, fmt.Errorf("failed to capture %s: %w", label, err)
+		}
+		name := sanitize(label) + ".log"
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content+"\n"), 0o644); err != nil {
+			return dir, err
+		}
+	}
+	return dir, nil
+}
@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
-	"path/filepath"
 	"strings"
+
+	"github.com/emilianotisato/vibeit/internal/config"
 )
 
 // SessionName generates a tmux session name for a workspace
@@ -28,7 +29,7 @@ func sanitize(s string) string {
 
 // SessionExists checks if a tmux session exists
 func SessionExists(sessionName string) bool {
-	cmd := exec.Command("tmux", "has-session", "-t", sessionName)
+	cmd := Command("has-session", "-t", sessionName)
 	return cmd.Run() == nil
 }
 
@@ -44,21 +45,35 @@ const (
 	TabNotes    TabType = "notes"
 )
 
-const defaultDetachKey = "C-\\"
-const defaultLastWindowKey = "C-]"
-const defaultOverviewKey = "F9"
+// state is everything the package remembers between calls
+type state struct {
+	// settings holds the tmux keys and tool commands in effect
+	settings config.Settings
+	// serverConfigOK is set once the generated config for the dedicated
+	// server was written; tmux refuses to start with a missing -f file
+	serverConfigOK bool
+	// dashboardReturn is what the detach key runs instead of detach-client
+	// while the dashboard lives on the same tmux server as its sessions
+	dashboardReturn string
+	// dashboardPopup is set when the dashboard runs in a popup that closes
+	// after every jump into a session
+	dashboardPopup bool
+}
+
+var current = state{settings: config.Defaults().Settings}
+
+// Configure applies the effective vibeit configuration. With tmux.socket set,
+// it also regenerates the dedicated server's config.
+func Configure(s config.Settings) {
+	current.settings = s
+	current.serverConfigOK = Socket() != "" && writeServerConfig() == nil
+}
 
 // TabCommand returns the command to run for a tab type
 func TabCommand(tabType TabType) string {
 	switch tabType {
-	case TabLazygit:
-		return "lazygit"
-	case TabClaude:
-		return "claude"
-	case TabCodex:
-		return "codex"
-	case TabNeovim:
-		return "nvim"
+	case TabLazygit, TabClaude, TabCodex, TabNeovim:
+		return current.settings.Tools[string(tabType)]
 	default:
 		return ""
 	}
@@ -66,7 +81,7 @@ func TabCommand(tabType TabType) string {
 
 // QueryTabNames returns all tmux window names for a session
 func QueryTabNames(sessionName string) ([]string, error) {
-	cmd := exec.Command("tmux", "list-windows", "-t", sessionName, "-F", "#W")
+	cmd := Command("list-windows", "-t", sessionName, "-F", "#W")
 	output, err := cmd.Output()
 	if err != nil {
 		return nil, err
@@ -123,138 +138,149 @@ func NextTabName(tabs []string, tabType TabType) string {
 	return fmt.Sprintf("%s-%d", prefix, maxNum+1)
 }
 
-// AttachOrCreateCmd returns a command that attaches to session, creating if needed
-func AttachOrCreateCmd(sessionName, workDir string) *exec.Cmd {
+// AttachOrCreateCmd returns a command that attaches to session, creating if needed.
+// env holds KEY=VALUE pairs for the session environment (see sessionEnvScript).
+func AttachOrCreateCmd(sessionName, workDir string, env []string) *exec.Cmd {
+	session := shellQuote(sessionName)
 	script := fmt.Sprintf(
-		`%stmux has-session -t %q 2>/dev/null && tmux attach -t %q || tmux new-session -s %q -c %q`,
+		`%sif tmux has-session -t %s 2>/dev/null; then `+
+			`%s%s`+
+			`else `+
+			`tmux new-session -d -s %s -c %s%s; `+
+			`%s`+
+			`fi`,
 		ensureDetachBindingScript(),
-		sessionName, sessionName, sessionName, workDir,
+		session,
+		sessionEnvScript(sessionName, env), attachScript(sessionName),
+		session, shellQuote(workDir), envFlags(env),
+		attachScript(sessionName),
 	)
-	cmd := exec.Command("sh", "-c", script)
-	cmd.Dir = workDir
-	return cmd
+	return scriptCmd(script, workDir)
 }
 
 // OpenWithCommand creates/attaches to session with a specific command running
-func OpenWithCommand(sessionName, workDir string, tabType TabType) *exec.Cmd {
+func OpenWithCommand(sessionName, workDir string, tabType TabType, env []string) *exec.Cmd {
 	command := TabCommand(tabType)
 	if command == "" {
-		return AttachOrCreateCmd(sessionName, workDir)
+		return AttachOrCreateCmd(sessionName, workDir, env)
 	}
 
-	tabName := string(tabType)
-	windowTarget := fmt.Sprintf("%s:%s", sessionName, tabName)
+	session := shellQuote(sessionName)
+	tabName := shellQuote(string(tabType))
+	dir := shellQuote(workDir)
 	script := fmt.Sprintf(
-		`%sif tmux has-session -t %q 2>/dev/null; then `+
-			`tmux new-window -t %q -n %q -c %q %q 2>/dev/null; `+
-			`tmux select-window -t %q 2>/dev/null; `+
-			`tmux attach -t %q; `+
+		`%sif tmux has-session -t %s 2>/dev/null; then `+
+			`%stmux new-window -t %s -n %s -c %s %s 2>/dev/null; `+
+			`tmux select-window -t %s 2>/dev/null; `+
+			`%s`+
 			`else `+
-			`tmux new-session -d -s %q -n %q -c %q %q; `+
-			`tmux attach -t %q; `+
+			`tmux new-session -d -s %s -n %s -c %s%s %s; `+
+			`%s`+
 			`fi`,
 		ensureDetachBindingScript(),
-		sessionName,
-		sessionName, tabName, workDir, command,
-		windowTarget,
-		sessionName,
-		sessionName, tabName, workDir, command,
-		sessionName,
+		session,
+		sessionEnvScript(sessionName, env), session, tabName, dir, shellQuote(command),
+		windowTarget(sessionName, string(tabType)),
+		attachScript(sessionName),
+		session, tabName, dir, envFlags(env), shellQuote(command),
+		attachScript(sessionName),
 	)
-	cmd := exec.Command("sh", "-c", script)
-	cmd.Dir = workDir
-	return cmd
+	return scriptCmd(script, workDir)
 }
 
 // GoToTabCmd returns a command that goes to a specific tab and attaches
-func GoToTabCmd(sessionName, workDir, tabName string) *exec.Cmd {
+func GoToTabCmd(sessionName, workDir, tabName string, env []string) *exec.Cmd {
+	session := shellQuote(sessionName)
 	script := fmt.Sprintf(
-		`%sif tmux has-session -t %q 2>/dev/null; then `+
-			`tmux select-window -t %q 2>/dev/null; `+
-			`tmux attach -t %q; `+
+		`%sif tmux has-session -t %s 2>/dev/null; then `+
+			`%stmux select-window -t %s 2>/dev/null; `+
+			`%s`+
 			`else `+
-			`tmux new-session -s %q -n %q -c %q; `+
+			`tmux new-session -d -s %s -n %s -c %s%s; `+
+			`%s`+
 			`fi`,
 		ensureDetachBindingScript(),
-		sessionName,
-		fmt.Sprintf("%s:%s", sessionName, tabName),
-		sessionName,
-		sessionName, tabName, workDir,
+		session,
+		sessionEnvScript(sessionName, env), windowTarget(sessionName, tabName),
+		attachScript(sessionName),
+		session, shellQuote(tabName), shellQuote(workDir), envFlags(env),
+		attachScript(sessionName),
 	)
-	cmd := exec.Command("sh", "-c", script)
-	cmd.Dir = workDir
-	return cmd
+	return scriptCmd(script, workDir)
 }
 
 // NewTabCmd creates a new tab with a command and attaches
-func NewTabCmd(sessionName, workDir, tabName string, tabType TabType) *exec.Cmd {
+func NewTabCmd(sessionName, workDir, tabName string, tabType TabType, env []string) *exec.Cmd {
 	command := TabCommand(tabType)
 	var cmdPart string
 	if command != "" {
-		cmdPart = fmt.Sprintf(" %q", command)
+		cmdPart = " " + shellQuote(command)
 	}
 
+	session := shellQuote(sessionName)
+	tab := shellQuote(tabName)
+	dir := shellQuote(workDir)
 	script := fmt.Sprintf(
-		`%sif tmux has-session -t %q 2>/dev/null; then `+
-			`tmux new-window -t %q -n %q -c %q%s 2>/dev/null; `+
-			`tmux select-window -t %q 2>/dev/null; `+
-			`tmux attach -t %q; `+
+		`%sif tmux has-session -t %s 2>/dev/null; then `+
+			`%stmux new-window -t %s -n %s -c %s%s 2>/dev/null; `+
+			`tmux select-window -t %s 2>/dev/null; `+
+			`%s`+
 			`else `+
-			`tmux new-session -d -s %q -n %q -c %q%s; `+
-			`tmux attach -t %q; `+
+			`tmux new-session -d -s %s -n %s -c %s%s%s; `+
+			`%s`+
 			`fi`,
 		ensureDetachBindingScript(),
-		sessionName,
-		sessionName, tabName, workDir, cmdPart,
-		fmt.Sprintf("%s:%s", sessionName, tabName),
-		sessionName,
-		sessionName, tabName, workDir, cmdPart,
-		sessionName,
+		session,
+		sessionEnvScript(sessionName, env), session, tab, dir, cmdPart,
+		windowTarget(sessionName, tabName),
+		attachScript(sessionName),
+		session, tab, dir, envFlags(env), cmdPart,
+		attachScript(sessionName),
 	)
-	cmd := exec.Command("sh", "-c", script)
-	cmd.Dir = workDir
-	return cmd
+	return scriptCmd(script, workDir)
 }
 
 // GoToOrCreateSingleTabCmd goes to a single-instance tab, creating if it doesn't exist
-func GoToOrCreateSingleTabCmd(sessionName, workDir string, tabType TabType) *exec.Cmd {
-	tabName := string(tabType)
+func GoToOrCreateSingleTabCmd(sessionName, workDir string, tabType TabType, env []string) *exec.Cmd {
 	command := TabCommand(tabType)
 	var cmdPart string
 	if command != "" {
-		cmdPart = fmt.Sprintf(" %q", command)
+		cmdPart = " " + shellQuote(command)
 	}
 
+	session := shellQuote(sessionName)
+	tab := shellQuote(string(tabType))
+	dir := shellQuote(workDir)
+	target := windowTarget(sessionName, string(tabType))
 	script := fmt.Sprintf(
-		`%sif tmux has-session -t %q 2>/dev/null; then `+
-			`tmux select-window -t %q 2>/dev/null || tmux new-window -t %q -n %q -c %q%s; `+
-			`tmux select-window -t %q 2>/dev/null; `+
-			`tmux attach -t %q; `+
+		`%sif tmux has-session -t %s 2>/dev/null; then `+
+			`%stmux select-window -t %s 2>/dev/null || tmux new-window -t %s -n %s -c %s%s; `+
+			`tmux select-window -t %s 2>/dev/null; `+
+			`%s`+
 			`else `+
-			`tmux new-session -s %q -n %q -c %q%s; `+
+			`tmux new-session -d -s %s -n %s -c %s%s%s; `+
+			`%s`+
 			`fi`,
 		ensureDetachBindingScript(),
-		sessionName,
-		fmt.Sprintf("%s:%s", sessionName, tabName),
-		sessionName, tabName, workDir, cmdPart,
-		fmt.Sprintf("%s:%s", sessionName, tabName),
-		sessionName,
-		sessionName, tabName, workDir, cmdPart,
+		session,
+		sessionEnvScript(sessionName, env), target, session, tab, dir, cmdPart,
+		target,
+		attachScript(sessionName),
+		session, tab, dir, envFlags(env), cmdPart,
+		attachScript(sessionName),
 	)
-	cmd := exec.Command("sh", "-c", script)
-	cmd.Dir = workDir
-	return cmd
+	return scriptCmd(script, workDir)
 }
 
 // DeleteSession deletes a tmux session
 func DeleteSession(sessionName string) error {
-	cmd := exec.Command("tmux", "kill-session", "-t", sessionName)
+	cmd := Command("kill-session", "-t", sessionName)
 	return cmd.Run()
 }
 
 // KillSession kills a tmux session
 func KillSession(sessionName string) error {
-	cmd := exec.Command("tmux", "kill-session", "-t", sessionName)
+	cmd := Command("kill-session", "-t", sessionName)
 	return cmd.Run()
 }
 
@@ -264,72 +290,114 @@ func IsTmuxInstalled() bool {
 	return err == nil
 }
 
-// OpenNotes opens a notes file in neovim
-func OpenNotesCmd(notesPath, workDir string) *exec.Cmd {
-	// Ensure the notes file exists
-	dir := filepath.Dir(notesPath)
-	os.MkdirAll(dir, 0755)
+// EditorCmd returns a command that opens a file in the configured editor,
+// nvim when none is set
+func EditorCmd(path string) *exec.Cmd {
+	editor := current.settings.Editor
+	if strings.TrimSpace(editor) == "" {
+		editor = "nvim"
+	}
+	return commandWithArgs(editor, path)
+}
 
-	// Create empty file if doesn't exist
-	if _, err := os.Stat(notesPath); os.IsNotExist(err) {
-		os.WriteFile(notesPath, []byte("# Notes\n\n"), 0644)
+// HasOpener reports whether browser_opener is set
+func HasOpener() bool {
+	return strings.TrimSpace(current.settings.BrowserOpener) != ""
+}
+
+// OpenerCmd returns a command that opens a file with the configured browser
+// opener, or nil when none is set
+func OpenerCmd(path string) *exec.Cmd {
+	if !HasOpener() {
+		return nil
 	}
+	return commandWithArgs(current.settings.BrowserOpener, path)
+}
+
+// commandWithArgs runs a configured command like `code --user-data-dir "/a b"`
+// with args appended. Like git with $EDITOR, sh splits the command, so quotes
+// and escapes work; args are passed as "$@" and never re-split.
+func commandWithArgs(command string, args ...string) *exec.Cmd {
+	return exec.Command("sh", append([]string{"-c", command + ` "$@"`, "sh"}, args...)...)
+}
+
+// shellQuote quotes a value for POSIX sh. Everything inside single quotes is
+// literal, so $(...), backticks and backslashes in branch names or paths
+// can't run; embedded single quotes are closed, escaped and reopened.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
 
-	cmd := exec.Command("nvim", notesPath)
-	cmd.Dir = workDir
-	return cmd
+// windowTarget returns the quoted tmux target for a session's tab
+func windowTarget(sessionName, tabName string) string {
+	return shellQuote(sessionName + ":" + tabName)
+}
+
+// sessionEnvScript refreshes the environment of an existing session. Like
+// -e on new-session, it only reaches windows created afterwards.
+func sessionEnvScript(sessionName string, env []string) string {
+	var script string
+	for _, kv := range env {
+		name, value, _ := strings.Cut(kv, "=")
+		script += fmt.Sprintf("tmux set-environment -t %s %s %s 2>/dev/null; ", shellQuote(sessionName), shellQuote(name), shellQuote(value))
+	}
+	return script
+}
+
+// envFlags turns KEY=VALUE pairs into new-session -e flags (tmux 3.2+)
+func envFlags(env []string) string {
+	var flags string
+	for _, kv := range env {
+		flags += " -e " + shellQuote(kv)
+	}
+	return flags
 }
 
 func ensureDetachBindingScript() string {
 	var script string
 
-	// Detach binding
+	// Detach binding, or back to the dashboard when it runs inside tmux
 	if key := tmuxDetachKey(); key != "" {
-		script += fmt.Sprintf("tmux bind-key -n %q detach-client 2>/dev/null; ", key)
+		action := "detach-client"
+		if current.dashboardReturn != "" {
+			action = current.dashboardReturn
+		}
+		script += fmt.Sprintf("tmux bind-key -n %s %s 2>/dev/null; ", shellQuote(key), action)
 	}
 
 	// Last window binding (switch to previous active tab)
 	if key := tmuxLastWindowKey(); key != "" {
-		script += fmt.Sprintf("tmux bind-key -n %q last-window 2>/dev/null; ", key)
+		script += fmt.Sprintf("tmux bind-key -n %s last-window 2>/dev/null; ", shellQuote(key))
 	}
 
 	// Toggle temporary overview grid for managed windows
 	if key := tmuxOverviewKey(); key != "" {
 		overviewCmd := tmuxOverviewCmd()
-		script += fmt.Sprintf("tmux bind-key -n %q run-shell %q 2>/dev/null; ", key, overviewCmd)
+		script += fmt.Sprintf("tmux bind-key -n %s run-shell %s 2>/dev/null; ", shellQuote(key), shellQuote(overviewCmd))
 	}
 
 	return script
 }
 
 func tmuxDetachKey() string {
-	if value := strings.TrimSpace(os.Getenv("VIBEIT_TMUX_DETACH_KEY")); value != "" {
-		if strings.EqualFold(value, "off") || strings.EqualFold(value, "none") {
-			return ""
-		}
-		return value
-	}
-	return defaultDetachKey
+	return tmuxKey(current.settings.Tmux.DetachKey)
 }
 
 func tmuxLastWindowKey() string {
-	if value := strings.TrimSpace(os.Getenv("VIBEIT_TMUX_LAST_WINDOW_KEY")); value != "" {
-		if strings.EqualFold(value, "off") || strings.EqualFold(value, "none") {
-			return ""
-		}
-		return value
-	}
-	return defaultLastWindowKey
+	return tmuxKey(current.settings.Tmux.LastWindowKey)
 }
 
 func tmuxOverviewKey() string {
-	if value := strings.TrimSpace(os.Getenv("VIBEIT_TMUX_OVERVIEW_KEY")); value != "" {
-		if strings.EqualFold(value, "off") || strings.EqualFold(value, "none") {
-			return ""
-		}
-		return value
+	return tmuxKey(current.settings.Tmux.OverviewKey)
+}
+
+// tmuxKey returns a configured key, or "" when the binding is turned off
+func tmuxKey(value string) string {
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, "off") || strings.EqualFold(value, "none") {
+		return ""
 	}
-	return defaultOverviewKey
+	return value
 }
 
 func tmuxOverviewCmd() string {
@@ -337,5 +405,6 @@ func tmuxOverviewCmd() string {
 	if err != nil || exePath == "" {
 		return "vibeit tmux-overview"
 	}
-	return fmt.Sprintf("%s tmux-overview", exePath)
+	// run-shell hands this to sh, so the path needs quoting too
+	return shellQuote(exePath) + " tmux-overview"
 }
@@ -0,0 +1,271 @@
+package mux
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/emilianotisato/vibeit/internal/config"
+)
+
+// hostileNames break out of naive quoting: command substitution, backticks,
+// quotes, backslashes and newlines
+var hostileNames = []string{
+	"plain",
+	"$(touch pwned)",
+	"`touch pwned`",
+	"it's",
+	`'; touch pwned; '`,
+	`back\slash\\`,
+	"new\nline; touch pwned",
+	`"$HOME" ${IFS} *`,
+	"-t",
+}
+
+func FuzzShellQuote(f *testing.F) {
+	for _, name := range hostileNames {
+		f.Add(name)
+	}
+	f.Add("")
+	f.Add("'''")
+	f.Add("\t\r\x1b[31m")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		if strings.ContainsRune(s, 0) {
+			t.Skip("arguments can't contain NUL")
+		}
+		cmd := exec.Command("sh", "-c", "printf %s "+shellQuote(s))
+		cmd.Dir = t.TempDir()
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("sh failed for %q: %v", s, err)
+		}
+		if string(out) != s {
+			t.Fatalf("round trip: got %q, want %q", out, s)
+		}
+	})
+}
+
+// stubTmux replaces tmux with a shell function that records each call's
+// arguments in $TMUX_LOG, NUL-terminated, with an RS byte after each call.
+// has-session fails when $STUB_NO_SESSION is set, to take the create branch.
+const stubTmux = `tmux() { ` +
+	`for a in "$@"; do printf '%s\0' "$a" >> "$TMUX_LOG"; done; printf '\036' >> "$TMUX_LOG"; ` +
+	`if [ "$1" = has-session ] && [ -n "$STUB_NO_SESSION" ]; then return 1; fi; ` +
+	`return 0; }; `
+
+// tmuxCall is one recorded invocation of the stubbed tmux
+type tmuxCall []string
+
+func runScript(t *testing.T, cmd *exec.Cmd, noSession bool) []tmuxCall {
+	t.Helper()
+	if cmd.Args[0] != "sh" || cmd.Args[1] != "-c" {
+		t.Fatalf("unexpected command %q", cmd.Args)
+	}
+	script := cmd.Args[2]
+
+	if out, err := exec.Command("sh", "-n", "-c", script).CombinedOutput(); err != nil {
+		t.Fatalf("script does not parse: %v\n%s\n%s", err, out, script)
+	}
+
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "tmux.log")
+	run := exec.Command("sh", "-c", stubTmux+script)
+	run.Dir = dir
+	run.Env = append(os.Environ(), "TMUX_LOG="+logPath)
+	if noSession {
+		run.Env = append(run.Env, "STUB_NO_SESSION=1")
+	}
+	if out, err := run.CombinedOutput(); err != nil {
+		t.Fatalf("script failed: %v\n%s", err, out)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if entry.Name() != "tmux.log" {
+			t.Fatalf("script created %q: a name was executed", entry.Name())
+		}
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var calls []tmuxCall
+	for _, record := range bytes.Split(data, []byte("\036")) {
+		if len(record) == 0 {
+			continue
+		}
+		fields := strings.Split(strings.TrimSuffix(string(record), "\x00"), "\x00")
+		calls = append(calls, tmuxCall(fields))
+	}
+	return calls
+}
+
+// findCall returns the first call whose leading arguments are prefix
+func findCall(calls []tmuxCall, prefix ...string) tmuxCall {
+	for _, call := range calls {
+		if len(call) >= len(prefix) && slices.Equal(call[:len(prefix)], prefix) {
+			return call
+		}
+	}
+	return nil
+}
+
+// flagValue returns the argument after flag, or "" when flag is missing
+func flagValue(call tmuxCall, flag string) string {
+	for i := 0; i+1 < len(call); i++ {
+		if call[i] == flag {
+			return call[i+1]
+		}
+	}
+	return ""
+}
+
+func TestScriptsPassHostileNamesLiterally(t *testing.T) {
+	t.Setenv("TMUX", "")
+	tools := map[string]string{"claude": "claude $(touch pwned)"}
+	useState(t, withTools(tools))
+
+	for _, name := range hostileNames {
+		session := "vibeit-" + name
+		workDir := "/tmp/" + name
+		tab := "term-" + name
+		env := []string{"VIBEIT_BRANCH=" + name}
+
+		t.Run(name, func(t *testing.T) {
+			for _, noSession := range []bool{false, true} {
+				calls := runScript(t, AttachOrCreateCmd(session, workDir, env), noSession)
+				if got := flagValue(findCall(calls, "attach"), "-t"); got != session {
+					t.Errorf("AttachOrCreate attach -t = %q, want %q", got, session)
+				}
+				if noSession {
+					created := findCall(calls, "new-session")
+					if got := flagValue(created, "-s"); got != session {
+						t.Errorf("AttachOrCreate new-session -s = %q, want %q", got, session)
+					}
+					if got := flagValue(created, "-c"); got != workDir {
+						t.Errorf("AttachOrCreate new-session -c = %q, want %q", got, workDir)
+					}
+					if got := flagValue(created, "-e"); got != env[0] {
+						t.Errorf("AttachOrCreate new-session -e = %q, want %q", got, env[0])
+					}
+				} else if got := findCall(calls, "set-environment"); len(got) != 5 || got[3] != "VIBEIT_BRANCH" || got[4] != name {
+					t.Errorf("AttachOrCreate set-environment = %q", got)
+				}
+
+				calls = runScript(t, NewTabCmd(session, workDir, tab, TabClaude, env), noSession)
+				want := "new-window"
+				if noSession {
+					want = "new-session"
+				}
+				created := findCall(calls, want)
+				if got := flagValue(created, "-n"); got != tab {
+					t.Errorf("NewTab %s -n = %q, want %q", want, got, tab)
+				}
+				if got := created[len(created)-1]; got != tools["claude"] {
+					t.Errorf("NewTab command = %q, want %q", got, tools["claude"])
+				}
+				if !noSession {
+					if got := flagValue(findCall(calls, "select-window"), "-t"); got != session+":"+tab {
+						t.Errorf("NewTab select-window -t = %q, want %q", got, session+":"+tab)
+					}
+				}
+
+				calls = runScript(t, GoToTabCmd(session, workDir, tab, env), noSession)
+				if got := flagValue(findCall(calls, "attach"), "-t"); got != session {
+					t.Errorf("GoToTab attach -t = %q, want %q", got, session)
+				}
+				if noSession {
+					if got := flagValue(findCall(calls, "new-session"), "-n"); got != tab {
+						t.Errorf("GoToTab new-session -n = %q, want %q", got, tab)
+					}
+				} else if got := flagValue(findCall(calls, "select-window"), "-t"); got != session+":"+tab {
+					t.Errorf("GoToTab select-window -t = %q, want %q", got, session+":"+tab)
+				}
+			}
+		})
+	}
+}
+
+func TestCommandWithArgsHonorsQuotes(t *testing.T) {
+	tests := []struct {
+		command string
+		args    []string
+		want    string
+	}{
+		{command: "printf %s.", args: []string{"file.md"}, want: "file.md."},
+		{command: `printf '%s|' --user-data-dir "/a b"`, args: []string{"notes.md"}, want: "--user-data-dir|/a b|notes.md|"},
+		{command: `printf '%s|' it\'s`, args: []string{"/tmp/my notes.md"}, want: "it's|/tmp/my notes.md|"},
+		{command: "printf '%s|'", args: []string{"$(touch pwned)"}, want: "$(touch pwned)|"},
+	}
+	for _, tt := range tests {
+		cmd := commandWithArgs(tt.command, tt.args...)
+		cmd.Dir = t.TempDir()
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%s: %v", tt.command, err)
+		}
+		if string(out) != tt.want {
+			t.Errorf("%s %q: got %q, want %q", tt.command, tt.args, out, tt.want)
+		}
+	}
+}
+
+func TestEnterDashboard(t *testing.T) {
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	t.Setenv("TMUX_PANE", "%3")
+
+	t.Run("pane", func(t *testing.T) {
+		useState(t, withTools(nil))
+		t.Setenv(dashboardEnv, "")
+		EnterDashboard("/work")
+		if got, want := current.dashboardReturn, "switch-client -t '%3'"; got != want {
+			t.Errorf("dashboardReturn = %q, want %q", got, want)
+		}
+		if QuitAfterAttach() {
+			t.Error("a pane dashboard should keep running after attaching")
+		}
+	})
+
+	t.Run("popup", func(t *testing.T) {
+		useState(t, withTools(nil))
+		t.Setenv(dashboardEnv, DashboardPopup)
+		EnterDashboard("/work")
+		if !strings.HasPrefix(current.dashboardReturn, "display-popup '-E' ") {
+			t.Errorf("dashboardReturn = %q, want a display-popup command", current.dashboardReturn)
+		}
+		if !QuitAfterAttach() {
+			t.Error("a popup dashboard should quit after attaching")
+		}
+		LeaveDashboard()
+		if current.dashboardReturn == "" {
+			t.Error("LeaveDashboard dropped the popup binding")
+		}
+	})
+}
+
+// useState gives a test fresh package state with settings s, restoring the
+// previous state when it ends
+func useState(t *testing.T, s config.Settings) {
+	t.Helper()
+	saved := current
+	t.Cleanup(func() { current = saved })
+	current = state{settings: s}
+}
+
+// withTools returns the default settings with tool commands replaced
+func withTools(tools map[string]string) config.Settings {
+	s := config.Defaults().Settings
+	for name, command := range tools {
+		s.Tools[name] = command
+	}
+	return s
+}
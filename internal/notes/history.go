@@ -0,0 +1,181 @@
+package notes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/emilianotisato/vibeit/internal/config"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	maxVersions   = 100
+	versionLayout = "20060102-150405.000"
+)
+
+// Version is a snapshot of a notes file
+type Version struct {
+	Path string
+	Time time.Time
+}
+
+// DiffLine is a line of a diff: ' ' unchanged, '+' added, '-' removed.
+// Runs of unchanged lines are collapsed into one line with Op '~'.
+type DiffLine struct {
+	Op   byte
+	Text string
+}
+
+// historyDir returns where snapshots of a notes file are kept
+func historyDir(path string) string {
+	sum := sha256.Sum256([]byte(path))
+	name := fmt.Sprintf("%s-%x", strings.TrimSuffix(filepath.Base(path), ".md"), sum[:6])
+	return filepath.Join(config.DataDir(), "notes-history", name)
+}
+
+// Snapshot saves the current content of a notes file when it differs from
+// the latest snapshot. Missing files are ignored.
+func Snapshot(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+
+	versions, err := History(path)
+	if err != nil {
+		return err
+	}
+	if len(versions) > 0 {
+		latest, err := os.ReadFile(versions[0].Path)
+		if err == nil && bytes.Equal(latest, data) {
+			return nil
+		}
+	}
+
+	dir := historyDir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	// Record which file the snapshots belong to
+	os.WriteFile(filepath.Join(dir, "source"), []byte(path+"\n"), 0o644)
+
+	stamp := time.Now().Truncate(time.Millisecond)
+	if len(versions) > 0 && !stamp.After(versions[0].Time) {
+		// Names have millisecond resolution; keep them unique and in order
+		stamp = versions[0].Time.Add(time.Millisecond)
+	}
+	name := stamp.Format(versionLayout) + ".md"
+	if err := os.WriteFile(filepath.Join(dir, name), data, 0o644); err != nil {
+		return err
+	}
+
+	// Drop the oldest snapshots beyond maxVersions, counting the new one
+	if len(versions) >= maxVersions {
+		for _, old := range versions[maxVersions-1:] {
+			os.Remove(old.Path)
+		}
+	}
+	return nil
+}
+
+// History lists the snapshots of a notes file, newest first
+func History(path string) ([]Version, error) {
+	entries, err := os.ReadDir(historyDir(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var versions []Version
+	for _, entry := range entries {
+		stamp, ok := strings.CutSuffix(entry.Name(), ".md")
+		if !ok {
+			continue
+		}
+		t, err := time.ParseInLocation(versionLayout, stamp, time.Local)
+		if err != nil {
+			continue
+		}
+		versions = append(versions, Version{Path: filepath.Join(historyDir(path), entry.Name()), Time: t})
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Time.After(versions[j].Time) })
+	return versions, nil
+}
+
+// Restore replaces a notes file with a snapshot, snapshotting the current
+// content first so the restore can itself be undone.
+func Restore(path string, version Version) error {
+	data, err := os.ReadFile(version.Path)
+	if err != nil {
+		return err
+	}
+	if err := Snapshot(path); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Diff compares two texts line by line, keeping context lines of unchanged
+// text around each change. Identical texts give no lines.
+func Diff(from, to string, context int) []DiffLine {
+	dmp := diffmatchpatch.New()
+	a, b, lines := dmp.DiffLinesToRunes(from, to)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMainRunes(a, b, false), lines)
+
+	var all []DiffLine
+	changed := false
+	for _, d := range diffs {
+		if d.Text == "" {
+			continue
+		}
+		op := byte(' ')
+		switch d.Type {
+		case diffmatchpatch.DiffInsert:
+			op, changed = '+', true
+		case diffmatchpatch.DiffDelete:
+			op, changed = '-', true
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(d.Text, "\n"), "\n") {
+			all = append(all, DiffLine{Op: op, Text: line})
+		}
+	}
+
+	if !changed {
+		return nil
+	}
+
+	// Keep unchanged lines only near a change
+	keep := make([]bool, len(all))
+	for i, line := range all {
+		if line.Op == ' ' {
+			continue
+		}
+		for j := max(i-context, 0); j <= min(i+context, len(all)-1); j++ {
+			keep[j] = true
+		}
+	}
+
+	var out []DiffLine
+	for i, line := range all {
+		if keep[i] {
+			out = append(out, line)
+		} else if len(out) == 0 || out[len(out)-1].Op != '~' {
+			out = append(out, DiffLine{Op: '~', Text: "…"})
+		}
+	}
+	return out
+}
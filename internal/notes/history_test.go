@@ -0,0 +1,114 @@
+package notes
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestSnapshotSkipsUnchangedContent(t *testing.T) {
+	path := writeNotes(t, "v1\n")
+	for range 3 {
+		if err := Snapshot(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+	versions, err := History(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 {
+		t.Fatalf("got %d versions, want 1", len(versions))
+	}
+
+	if err := Snapshot(path + ".missing"); err != nil {
+		t.Errorf("missing file: %v", err)
+	}
+}
+
+func TestSnapshotPrunesOldest(t *testing.T) {
+	path := writeNotes(t, "")
+	total := maxVersions + 5
+	for i := range total {
+		if err := os.WriteFile(path, []byte(fmt.Sprintf("v%d\n", i)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := Snapshot(path); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	versions, err := History(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != maxVersions {
+		t.Fatalf("got %d versions, want %d", len(versions), maxVersions)
+	}
+	if got := readFile(t, versions[0].Path); got != fmt.Sprintf("v%d\n", total-1) {
+		t.Errorf("newest = %q", got)
+	}
+	if got := readFile(t, versions[len(versions)-1].Path); got != fmt.Sprintf("v%d\n", total-maxVersions) {
+		t.Errorf("oldest = %q", got)
+	}
+	if !slices.IsSortedFunc(versions, func(a, b Version) int { return b.Time.Compare(a.Time) }) {
+		t.Error("history not newest first")
+	}
+}
+
+func TestRestoreSnapshotsCurrentContent(t *testing.T) {
+	path := writeNotes(t, "v1\n")
+	if err := Snapshot(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("v2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := History(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Restore(path, versions[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := readFile(t, path); got != "v1\n" {
+		t.Errorf("restored content = %q", got)
+	}
+	versions, err = History(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 2 || readFile(t, versions[0].Path) != "v2\n" {
+		t.Fatalf("want the pre-restore content as the newest of 2 versions, got %+v", versions)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\n"
+	to := "a\nb\nc\nD\ne\nf\ng\nh\n"
+
+	got := Diff(from, to, 1)
+	want := []DiffLine{
+		{'~', "…"},
+		{' ', "c"},
+		{'-', "d"},
+		{'+', "D"},
+		{' ', "e"},
+		{'~', "…"},
+		{' ', "g"},
+		{'+', "h"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("got  %q\nwant %q", got, want)
+	}
+
+	if got := Diff(from, from, 3); got != nil {
+		t.Errorf("identical texts gave %q", got)
+	}
+	if got := Diff("", "new\n", 3); !slices.Equal(got, []DiffLine{{'+', "new"}}) {
+		t.Errorf("from empty: %q", got)
+	}
+}
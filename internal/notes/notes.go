@@ -0,0 +1,204 @@
+// Package notes manages markdown notes kept next to the project, outside
+// the repository: one shared {project}.md plus one file per branch under
+// {project}-notes/.
+package notes
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+const indexFile = ".index.json"
+
+// indexMu serialises index updates from concurrent git status refreshes
+var indexMu sync.Mutex
+
+// Store locates the notes of one project
+type Store struct {
+	projectName string
+	parentDir   string
+}
+
+// Note is a notes file known to the store
+type Note struct {
+	Name   string // branch name, or the project name for the shared file
+	Path   string
+	Shared bool
+}
+
+// index remembers which branch each workspace had, so a renamed branch can
+// take its notes along
+type index struct {
+	Workspaces map[string]string `json:"workspaces"` // workspace path -> branch
+}
+
+// Open returns the store for a project whose main repo is projectPath
+func Open(projectPath, projectName string) Store {
+	return Store{projectName: projectName, parentDir: filepath.Dir(projectPath)}
+}
+
+// Dir returns the folder holding per-branch notes
+func (s Store) Dir() string {
+	return filepath.Join(s.parentDir, s.projectName+"-notes")
+}
+
+// SharedPath returns the project-wide notes file
+func (s Store) SharedPath() string {
+	return filepath.Join(s.parentDir, s.projectName+".md")
+}
+
+// BranchPath returns the notes file for a branch. Branch names with slashes
+// map to subfolders, so "feature/x" and "feature-x" never collide.
+func (s Store) BranchPath(branch string) string {
+	return filepath.Join(s.Dir(), filepath.FromSlash(branch)+".md")
+}
+
+// Resolve returns the notes file for a workspace's current branch. When the
+// branch recorded for the workspace was renamed into the current one, its
+// notes are moved to the new name first. Checking out a new branch and then
+// deleting the old one is not a rename; those notes stay where they are.
+func (s Store) Resolve(workspacePath, branch string) (string, error) {
+	path := s.BranchPath(branch)
+
+	indexMu.Lock()
+	defer indexMu.Unlock()
+
+	idx := s.loadIndex()
+	previous, known := idx.Workspaces[workspacePath]
+	if known && previous == branch {
+		return path, nil
+	}
+
+	if known && previous != "" && !branchExists(workspacePath, previous) && renamedFrom(workspacePath, previous, branch) {
+		if err := s.migrate(previous, branch); err != nil {
+			return path, err
+		}
+	}
+
+	idx.Workspaces[workspacePath] = branch
+	return path, s.saveIndex(idx)
+}
+
+// migrate moves notes from a renamed branch, never overwriting existing notes
+func (s Store) migrate(from, to string) error {
+	oldPath, newPath := s.BranchPath(from), s.BranchPath(to)
+	if _, err := os.Stat(oldPath); err != nil {
+		return nil
+	}
+	if _, err := os.Stat(newPath); err == nil {
+		return fmt.Errorf("notes for %s and %s both exist, not merging", from, to)
+	}
+	if err := os.MkdirAll(filepath.Dir(newPath), 0o755); err != nil {
+		return err
+	}
+	return os.Rename(oldPath, newPath)
+}
+
+func branchExists(workspacePath, branch string) bool {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	cmd.Dir = workspacePath
+	return cmd.Run() == nil
+}
+
+// renamedFrom reports whether the branch's reflog records `git branch -m from
+// branch`, as "Branch: renamed refs/heads/from to refs/heads/branch"
+func renamedFrom(workspacePath, from, branch string) bool {
+	cmd := exec.Command("git", "log", "--walk-reflogs", "--format=%gs", "refs/heads/"+branch, "--")
+	cmd.Dir = workspacePath
+	out, err := cmd.Output()
+	if err != nil {
+		return false
+	}
+	rename := "renamed refs/heads/" + from + " to refs/heads/" + branch
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.HasSuffix(line, rename) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Store) loadIndex() index {
+	idx := index{Workspaces: map[string]string{}}
+	data, err := os.ReadFile(filepath.Join(s.Dir(), indexFile))
+	if err != nil {
+		return idx
+	}
+	json.Unmarshal(data, &idx)
+	if idx.Workspaces == nil {
+		idx.Workspaces = map[string]string{}
+	}
+	return idx
+}
+
+func (s Store) saveIndex(idx index) error {
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir(), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(s.Dir(), indexFile), data, 0o644)
+}
+
+// List returns the shared notes followed by every branch notes file
+func (s Store) List() ([]Note, error) {
+	notes := []Note{{Name: s.projectName, Path: s.SharedPath(), Shared: true}}
+
+	var branches []Note
+	err := filepath.WalkDir(s.Dir(), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.Dir(), path)
+		if err != nil {
+			return err
+		}
+		branches = append(branches, Note{Name: filepath.ToSlash(strings.TrimSuffix(rel, ".md")), Path: path})
+		return nil
+	})
+	sort.Slice(branches, func(i, j int) bool { return branches[i].Name < branches[j].Name })
+	return append(notes, branches...), err
+}
+
+// Ensure creates a notes file with the given content when it doesn't exist
+func Ensure(path, content string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644)
+}
+
+// Preview returns up to max lines of a notes file and whether it exists
+func Preview(path string, max int) (bool, []string) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, nil
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() && len(lines) < max {
+		lines = append(lines, scanner.Text())
+	}
+	return true, lines
+}
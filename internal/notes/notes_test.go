@@ -0,0 +1,103 @@
+package notes
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// git runs a git command in dir with a fixed identity
+func git(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// newRepo creates a project repo on branch feature/a with notes recorded for it
+func newRepo(t *testing.T) (Store, string) {
+	t.Helper()
+	repo := filepath.Join(t.TempDir(), "proj")
+	if err := os.Mkdir(repo, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	git(t, repo, "init", "-q", "-b", "main")
+	git(t, repo, "commit", "-q", "--allow-empty", "-m", "init")
+	git(t, repo, "checkout", "-q", "-b", "feature/a")
+
+	store := Open(repo, "proj")
+	path, err := store.Resolve(repo, "feature/a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("# feature/a\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return store, repo
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestResolveMovesNotesOnRename(t *testing.T) {
+	store, repo := newRepo(t)
+	git(t, repo, "branch", "-m", "feature/a", "feature/b")
+
+	path, err := store.Resolve(repo, "feature/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != store.BranchPath("feature/b") {
+		t.Fatalf("path = %s", path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || string(data) != "# feature/a\n" {
+		t.Fatalf("notes not moved: %q, %v", data, err)
+	}
+	if exists(store.BranchPath("feature/a")) {
+		t.Error("old notes left behind")
+	}
+}
+
+func TestResolveKeepsNotesOnCheckoutThenDelete(t *testing.T) {
+	store, repo := newRepo(t)
+	git(t, repo, "checkout", "-q", "-b", "feature/b")
+	git(t, repo, "branch", "-D", "feature/a")
+
+	if _, err := store.Resolve(repo, "feature/b"); err != nil {
+		t.Fatal(err)
+	}
+	if !exists(store.BranchPath("feature/a")) {
+		t.Error("notes of the deleted branch were moved")
+	}
+	if exists(store.BranchPath("feature/b")) {
+		t.Error("notes for the new branch were created from the deleted one")
+	}
+}
+
+func TestResolveNeverOverwrites(t *testing.T) {
+	store, repo := newRepo(t)
+	target := store.BranchPath("feature/b")
+	if err := os.WriteFile(target, []byte("# feature/b\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	git(t, repo, "branch", "-m", "feature/a", "feature/b")
+
+	if _, err := store.Resolve(repo, "feature/b"); err == nil {
+		t.Error("expected an error when both notes exist")
+	}
+	if data, _ := os.ReadFile(target); string(data) != "# feature/b\n" {
+		t.Errorf("existing notes overwritten: %q", data)
+	}
+	if !exists(store.BranchPath("feature/a")) {
+		t.Error("old notes removed")
+	}
+}
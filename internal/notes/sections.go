@@ -0,0 +1,82 @@
+package notes
+
+import (
+	"strings"
+)
+
+// Section is a heading of a notes file and the text under it, including
+// its subsections
+type Section struct {
+	Title string
+	Level int
+	Body  string
+}
+
+// Sections splits markdown into its headings. Headings inside fenced code
+// blocks are ignored.
+func Sections(text string) []Section {
+	lines := strings.Split(text, "\n")
+
+	type heading struct{ line, level int }
+	var headings []heading
+	for i, level := range headingLevels(lines) {
+		if level > 0 {
+			headings = append(headings, heading{i, level})
+		}
+	}
+
+	var sections []Section
+	for i, h := range headings {
+		end := len(lines)
+		for _, next := range headings[i+1:] {
+			if next.level <= h.level {
+				end = next.line
+				break
+			}
+		}
+		sections = append(sections, Section{
+			Title: strings.TrimSpace(lines[h.line][h.level:]),
+			Level: h.level,
+			Body:  strings.TrimSpace(strings.Join(lines[h.line:end], "\n")) + "\n",
+		})
+	}
+	return sections
+}
+
+// headingLevels returns the level of every line that is a "#" heading and 0
+// for other lines. Lines inside fenced code blocks are never headings; a
+// fence closes only with the character it opened with, at least as long.
+func headingLevels(lines []string) []int {
+	levels := make([]int, len(lines))
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				fence = ""
+			}
+			continue
+		}
+		if marker := fenceMarker(trimmed); marker != "" {
+			fence = marker
+			continue
+		}
+		level := len(line) - len(strings.TrimLeft(line, "#"))
+		if level >= 1 && level <= 6 && strings.HasPrefix(line[level:], " ") {
+			levels[i] = level
+		}
+	}
+	return levels
+}
+
+// fenceMarker returns the run of backticks or tildes opening a code fence,
+// or "" when the line doesn't open one
+func fenceMarker(trimmed string) string {
+	for _, c := range []string{"`", "~"} {
+		marker := trimmed[:len(trimmed)-len(strings.TrimLeft(trimmed, c))]
+		if len(marker) >= 3 {
+			return marker
+		}
+	}
+	return ""
+}
@@ -0,0 +1,33 @@
+package notes
+
+import (
+	"testing"
+)
+
+func TestSections(t *testing.T) {
+	text := "# Branch\n\nintro\n\n## Goal\n\nship it\n\n```sh\n# not a heading\n## nor this\n```\n\n### Detail\n\nmore\n\n## Links\n\n~~~\n# still code\n```\n# fenced by tildes, a backtick line doesn't close it\n~~~\n\n####### seven is not a heading\n#no space\n"
+	sections := Sections(text)
+
+	want := []Section{
+		{Title: "Branch", Level: 1, Body: text[:len(text)-1] + "\n"},
+		{Title: "Goal", Level: 2, Body: "## Goal\n\nship it\n\n```sh\n# not a heading\n## nor this\n```\n\n### Detail\n\nmore\n"},
+		{Title: "Detail", Level: 3, Body: "### Detail\n\nmore\n"},
+		{Title: "Links", Level: 2, Body: "## Links\n\n~~~\n# still code\n```\n# fenced by tildes, a backtick line doesn't close it\n~~~\n\n####### seven is not a heading\n#no space\n"},
+	}
+	if len(sections) != len(want) {
+		t.Fatalf("got %d sections: %+v", len(sections), sections)
+	}
+	for i := range want {
+		if sections[i] != want[i] {
+			t.Errorf("section %d:\ngot  %+v\nwant %+v", i, sections[i], want[i])
+		}
+	}
+}
+
+func TestSectionsLongerFence(t *testing.T) {
+	text := "````md\n```\n# inside\n```\n````\n# After\n"
+	sections := Sections(text)
+	if len(sections) != 1 || sections[0].Title != "After" {
+		t.Fatalf("got %+v, want only the heading after the fence", sections)
+	}
+}
@@ -0,0 +1,148 @@
+package notes
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// DefaultTemplate seeds new branch notes when no template is configured
+const DefaultTemplate = `# {{.Branch}}
+
+{{if .BaseBranch}}Base: {{.BaseBranch}} · {{end}}Created: {{.Date}} · Workspace: {{.Path}}
+
+## Goal
+
+## Acceptance criteria
+
+## Agent prompts
+
+## Links
+`
+
+const sessionLogHeading = "## Session log"
+
+// TemplateData is the data available to notes templates, e.g.
+//
+//	# {{.Branch}} (from {{.BaseBranch}}, {{.Date}})
+type TemplateData struct {
+	Project    string
+	Workspace  string
+	Branch     string
+	BaseBranch string
+	Date       string
+	Path       string
+	MainPath   string
+}
+
+// NewTemplateData collects template variables for a workspace, dated today
+func NewTemplateData(project, workspace, branch, baseBranch, path, mainPath string) TemplateData {
+	return TemplateData{
+		Project:    project,
+		Workspace:  workspace,
+		Branch:     branch,
+		BaseBranch: baseBranch,
+		Date:       time.Now().Format("2006-01-02"),
+		Path:       path,
+		MainPath:   mainPath,
+	}
+}
+
+// LoadTemplate reads a template file. Relative paths are resolved against
+// the main repo and "~/" against the home directory; empty returns the default.
+func LoadTemplate(path, mainPath string) (string, error) {
+	if path == "" {
+		return DefaultTemplate, nil
+	}
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	} else if !filepath.IsAbs(path) {
+		path = filepath.Join(mainPath, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("notes template: %w", err)
+	}
+	return string(data), nil
+}
+
+// Render fills a notes template
+func Render(text string, data TemplateData) (string, error) {
+	tmpl, err := template.New("notes").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("notes template: %w", err)
+	}
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("notes template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// AppendLog adds a timestamped entry to the "## Session log" section of a
+// notes file, creating the section at the end when missing.
+func AppendLog(path, entry string) error {
+	if err := Snapshot(path); err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	line := fmt.Sprintf("- %s %s", time.Now().Format("2006-01-02 15:04"), entry)
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+	levels := headingLevels(lines)
+	section := -1
+	for i, l := range lines {
+		if levels[i] > 0 && strings.TrimSpace(l) == sessionLogHeading {
+			section = i
+			break
+		}
+	}
+
+	if section == -1 {
+		lines = append(lines, "", sessionLogHeading, "", line)
+	} else {
+		// Insert after the section's last non-blank line, before the next heading
+		end := len(lines)
+		for i := section + 1; i < len(lines); i++ {
+			if levels[i] > 0 {
+				end = i
+				break
+			}
+		}
+		at := end
+		for at > section+1 && strings.TrimSpace(lines[at-1]) == "" {
+			at--
+		}
+		if at == section+1 {
+			lines = insert(lines, at, "", line)
+		} else {
+			lines = insert(lines, at, line)
+		}
+	}
+
+	return os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), info.Mode().Perm())
+}
+
+func insert(lines []string, at int, add ...string) []string {
+	out := make([]string, 0, len(lines)+len(add))
+	out = append(out, lines[:at]...)
+	out = append(out, add...)
+	return append(out, lines[at:]...)
+}
@@ -0,0 +1,75 @@
+package notes
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// logStamp matches the timestamp AppendLog puts before each entry
+var logStamp = regexp.MustCompile(`(?m)^- \d{4}-\d{2}-\d{2} \d{2}:\d{2} `)
+
+func TestAppendLog(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "creates the section at the end",
+			content: "# feature\n\n## Goal\n\nship it\n\n",
+			want:    "# feature\n\n## Goal\n\nship it\n\n## Session log\n\n- entry\n",
+		},
+		{
+			name:    "appends to an existing section",
+			content: "# feature\n\n## Session log\n\n- first\n\n## Links\n",
+			want:    "# feature\n\n## Session log\n\n- first\n- entry\n\n## Links\n",
+		},
+		{
+			name:    "fills an empty section",
+			content: "## Session log\n## Links\n",
+			want:    "## Session log\n\n- entry\n## Links\n",
+		},
+		{
+			name:    "skips fenced lines that look like headings",
+			content: "## Session log\n\n```sh\n# run\n## Session log\n```\n- first\n\n## Links\n",
+			want:    "## Session log\n\n```sh\n# run\n## Session log\n```\n- first\n- entry\n\n## Links\n",
+		},
+		{
+			name:    "ignores a fenced session log heading",
+			content: "# feature\n\n```md\n## Session log\n```\n",
+			want:    "# feature\n\n```md\n## Session log\n```\n\n## Session log\n\n- entry\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeNotes(t, tt.content)
+			if err := AppendLog(path, "entry"); err != nil {
+				t.Fatal(err)
+			}
+			got := readFile(t, path)
+			if stamps := logStamp.FindAllString(got, -1); len(stamps) != 1 {
+				t.Fatalf("want one timestamped entry, got %q", got)
+			}
+			if got = logStamp.ReplaceAllString(got, "- "); got != tt.want {
+				t.Errorf("got  %q\nwant %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTemplate(t *testing.T) {
+	data := TemplateData{Branch: "feature/x", BaseBranch: "main", Date: "2026-01-02", Path: "/w"}
+	out, err := Render(DefaultTemplate, data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "# feature/x\n\nBase: main · Created: 2026-01-02 · Workspace: /w\n") {
+		t.Errorf("got %q", out)
+	}
+
+	if _, err := Render("{{.Missing}}", data); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
@@ -0,0 +1,127 @@
+package notes
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// checkbox matches markdown task list items such as "- [ ] write tests"
+var checkbox = regexp.MustCompile(`^(\s*[-*+] \[)([ xX])(\]\s*)(.*)$`)
+
+// Todo is a checklist item in a notes file
+type Todo struct {
+	Path string
+	Line int // 1-based
+	Text string
+	Done bool
+}
+
+// Hit is a notes line matching a search
+type Hit struct {
+	Path string
+	Line int // 1-based
+	Text string
+}
+
+// Todos returns every checklist item in a notes file, checked or not
+func Todos(path string) ([]Todo, error) {
+	lines, err := readLines(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var todos []Todo
+	for i, line := range lines {
+		parts := checkbox.FindStringSubmatch(line)
+		if parts == nil {
+			continue
+		}
+		todos = append(todos, Todo{Path: path, Line: i + 1, Text: parts[4], Done: parts[2] != " "})
+	}
+	return todos, nil
+}
+
+// OpenTodos counts the unchecked items in a notes file
+func OpenTodos(path string) int {
+	todos, _ := Todos(path)
+	count := 0
+	for _, todo := range todos {
+		if !todo.Done {
+			count++
+		}
+	}
+	return count
+}
+
+// Toggle checks or unchecks an item in place. It refuses when the file was
+// edited since the item was read and the line no longer holds it.
+func Toggle(todo Todo) error {
+	if err := Snapshot(todo.Path); err != nil {
+		return err
+	}
+	info, err := os.Stat(todo.Path)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(todo.Path)
+	if err != nil {
+		return err
+	}
+
+	lines := strings.Split(string(data), "\n")
+	if todo.Line < 1 || todo.Line > len(lines) {
+		return fmt.Errorf("%s changed, reload and try again", todo.Path)
+	}
+	line := strings.TrimSuffix(lines[todo.Line-1], "\r")
+	parts := checkbox.FindStringSubmatch(line)
+	if parts == nil || parts[4] != todo.Text {
+		return fmt.Errorf("%s changed, reload and try again", todo.Path)
+	}
+
+	mark := "x"
+	if parts[2] != " " {
+		mark = " "
+	}
+	lines[todo.Line-1] = parts[1] + mark + lines[todo.Line-1][len(parts[1])+1:]
+	return os.WriteFile(todo.Path, []byte(strings.Join(lines, "\n")), info.Mode().Perm())
+}
+
+// Search returns lines containing query, ignoring case, across notes files
+func Search(paths []string, query string) []Hit {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return nil
+	}
+
+	var hits []Hit
+	for _, path := range paths {
+		lines, err := readLines(path)
+		if err != nil {
+			continue
+		}
+		for i, line := range lines {
+			if strings.Contains(strings.ToLower(line), query) {
+				hits = append(hits, Hit{Path: path, Line: i + 1, Text: strings.TrimSpace(line)})
+			}
+		}
+	}
+	return hits
+}
+
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines, scanner.Err()
+}
@@ -0,0 +1,163 @@
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeNotes writes content to a notes file in a temp dir, with history kept
+// in a temp data dir
+func writeNotes(t *testing.T, content string) string {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestTodos(t *testing.T) {
+	path := writeNotes(t, "# Goal\n- [ ] top\n  - [x] nested\n\t* [X] tab indented\n+ [ ] plus\n- [] not a box\n-[ ] no space\n")
+	todos, err := Todos(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Todo{
+		{Path: path, Line: 2, Text: "top"},
+		{Path: path, Line: 3, Text: "nested", Done: true},
+		{Path: path, Line: 4, Text: "tab indented", Done: true},
+		{Path: path, Line: 5, Text: "plus"},
+	}
+	if len(todos) != len(want) {
+		t.Fatalf("got %+v, want %+v", todos, want)
+	}
+	for i := range want {
+		if todos[i] != want[i] {
+			t.Errorf("todo %d = %+v, want %+v", i, todos[i], want[i])
+		}
+	}
+	if got := OpenTodos(path); got != 2 {
+		t.Errorf("OpenTodos = %d, want 2", got)
+	}
+}
+
+func TestToggle(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		want    string
+	}{
+		{name: "check", content: "# Goal\n- [ ] write tests\n", line: 2, want: "# Goal\n- [x] write tests\n"},
+		{name: "uncheck", content: "- [X] done\n", line: 1, want: "- [ ] done\n"},
+		{name: "nested", content: "- [ ] parent\n    - [ ] child\n", line: 2, want: "- [ ] parent\n    - [x] child\n"},
+		{name: "tab indented star", content: "\t* [ ] item", line: 1, want: "\t* [x] item"},
+		{name: "crlf", content: "# Goal\r\n- [ ] item\r\n- [ ] other\r\n", line: 2, want: "# Goal\r\n- [x] item\r\n- [ ] other\r\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeNotes(t, tt.content)
+			todos, err := Todos(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var todo Todo
+			for _, candidate := range todos {
+				if candidate.Line == tt.line {
+					todo = candidate
+				}
+			}
+			if todo.Path == "" {
+				t.Fatalf("no todo on line %d in %+v", tt.line, todos)
+			}
+
+			if err := Toggle(todo); err != nil {
+				t.Fatal(err)
+			}
+			if got := readFile(t, path); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestToggleRefusesChangedFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		edited string
+	}{
+		{name: "text edited", edited: "# Goal\n- [ ] write more tests\n"},
+		{name: "line moved", edited: "# Goal\n\n- [ ] write tests\n"},
+		{name: "file shortened", edited: "# Goal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeNotes(t, "# Goal\n- [ ] write tests\n")
+			todos, err := Todos(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, []byte(tt.edited), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			if err := Toggle(todos[0]); err == nil || !strings.Contains(err.Error(), "changed") {
+				t.Fatalf("got %v, want a changed file error", err)
+			}
+			if got := readFile(t, path); got != tt.edited {
+				t.Errorf("file modified: %q", got)
+			}
+		})
+	}
+}
+
+func TestToggleSnapshotsFirst(t *testing.T) {
+	path := writeNotes(t, "- [ ] item\n")
+	todos, err := Todos(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Toggle(todos[0]); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := History(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(versions) != 1 || readFile(t, versions[0].Path) != "- [ ] item\n" {
+		t.Fatalf("want one snapshot of the unchecked file, got %+v", versions)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	a := writeNotes(t, "# Goal\nShip the Parser\n")
+	b := filepath.Join(filepath.Dir(a), "other.md")
+	if err := os.WriteFile(b, []byte("  parser notes  \n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	hits := Search([]string{a, b, filepath.Join(filepath.Dir(a), "missing.md")}, " PARSER ")
+	want := []Hit{{Path: a, Line: 2, Text: "Ship the Parser"}, {Path: b, Line: 1, Text: "parser notes"}}
+	if len(hits) != len(want) || hits[0] != want[0] || hits[1] != want[1] {
+		t.Fatalf("got %+v, want %+v", hits, want)
+	}
+	if hits := Search([]string{a}, "  "); hits != nil {
+		t.Errorf("blank query matched %+v", hits)
+	}
+}
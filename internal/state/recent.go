@@ -0,0 +1,69 @@
+// Package state persists small bits of vibeit state under the XDG state dir.
+package state
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/emilianotisato/vibeit/internal/config"
+)
+
+const maxRecentFiles = 20
+
+// recentFiles maps a project's main repo path to workspace-relative paths,
+// most recent first
+type recentFiles map[string][]string
+
+func recentPath() string {
+	dir := config.StateDir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "recent-files.json")
+}
+
+func loadRecent() recentFiles {
+	recent := recentFiles{}
+	path := recentPath()
+	if path == "" {
+		return recent
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return recent
+	}
+	json.Unmarshal(data, &recent)
+	return recent
+}
+
+// RecentFiles returns recently opened files for a project, most recent first
+func RecentFiles(projectPath string) []string {
+	return loadRecent()[projectPath]
+}
+
+// AddRecentFile records that a workspace-relative file was opened
+func AddRecentFile(projectPath, relPath string) error {
+	path := recentPath()
+	if path == "" {
+		return nil
+	}
+
+	recent := loadRecent()
+	files := []string{relPath}
+	for _, f := range recent[projectPath] {
+		if f != relPath && len(files) < maxRecentFiles {
+			files = append(files, f)
+		}
+	}
+	recent[projectPath] = files
+
+	data, err := json.MarshalIndent(recent, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
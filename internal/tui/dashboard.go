@@ -0,0 +1,219 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/emilianotisato/vibeit/internal/mux"
+	"github.com/emilianotisato/vibeit/internal/workspace"
+)
+
+const (
+	cardWidth  = 34 // inner width, without border
+	cardHeight = 5
+)
+
+// shells are pane commands that mean an agent tab has exited to its prompt
+var shells = map[string]bool{"sh": true, "bash": true, "zsh": true, "fish": true, "dash": true, "nu": true}
+
+// cardStatus is the tmux state shown on a dashboard card
+type cardStatus struct {
+	session bool
+	tabs    int
+	agents  []agentStatus
+}
+
+type agentStatus struct {
+	name    string
+	running bool
+}
+
+type cardStatusMsg map[string]cardStatus
+
+// loadCardStatus queries tmux for every workspace, keyed by workspace path
+func loadCardStatus(projectName string, workspaces []workspace.Workspace) tea.Cmd {
+	wsSnapshot := make([]workspace.Workspace, len(workspaces))
+	copy(wsSnapshot, workspaces)
+
+	return func() tea.Msg {
+		status := cardStatusMsg{}
+		for _, ws := range wsSnapshot {
+			sessionName := mux.SessionName(projectName, ws.Name, ws.Branch)
+			if !mux.SessionExists(sessionName) {
+				status[ws.Path] = cardStatus{}
+				continue
+			}
+			card := cardStatus{session: true}
+			windows, _ := mux.ListWindows(sessionName)
+			for _, w := range windows {
+				if !isManagedTabName(w.Name) {
+					continue
+				}
+				card.tabs++
+				if isAgentTab(w.Name) {
+					running := len(w.Commands) > 0 && !shells[w.Commands[0]]
+					card.agents = append(card.agents, agentStatus{name: w.Name, running: running})
+				}
+			}
+			status[ws.Path] = card
+		}
+		return status
+	}
+}
+
+func isAgentTab(name string) bool {
+	for _, agent := range []mux.TabType{mux.TabClaude, mux.TabCodex} {
+		if len(mux.FilterTabsByPrefix([]string{name}, string(agent))) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (m Model) toggleGrid() (tea.Model, tea.Cmd) {
+	m.gridMode = !m.gridMode
+	if !m.gridMode {
+		return m, nil
+	}
+	return m, loadCardStatus(m.projectName, m.workspaces)
+}
+
+// gridColumns returns how many cards fit side by side
+func (m Model) gridColumns() int {
+	cols := (m.width - 4) / (cardWidth + 2)
+	return max(cols, 1)
+}
+
+// handleGridInput moves the selection between cards. Other keys fall through
+// to the regular keymap and act on the selected workspace.
+func (m Model) handleGridInput(msg tea.KeyMsg) (tea.Model, tea.Cmd, bool) {
+	cols := m.gridColumns()
+	target := m.activeIdx
+
+	switch {
+	case key.Matches(msg, gridKeys.Left):
+		target--
+	case key.Matches(msg, gridKeys.Right):
+		target++
+	case key.Matches(msg, gridKeys.Up):
+		target -= cols
+	case key.Matches(msg, gridKeys.Down):
+		target += cols
+	case key.Matches(msg, gridKeys.Back):
+		m.gridMode = false
+		return m, nil, true
+	default:
+		return m, nil, false
+	}
+
+	if target < 0 || target >= len(m.workspaces) {
+		return m, nil, true
+	}
+	model, cmd := m.activate(target)
+	return model, cmd, true
+}
+
+func (m Model) renderGrid(height int) string {
+	if len(m.workspaces) == 0 {
+		return mainContentStyle.Height(height).Render("No workspaces found")
+	}
+
+	cols := m.gridColumns()
+	var rows []string
+	for start := 0; start < len(m.workspaces); start += cols {
+		var cards []string
+		for i := start; i < min(start+cols, len(m.workspaces)); i++ {
+			cards = append(cards, m.renderCard(i))
+		}
+		rows = append(rows, lipgloss.JoinHorizontal(lipgloss.Top, cards...))
+	}
+
+	first := m.gridFirstRow(height)
+	last := min(first+m.gridVisibleRows(height), len(rows))
+
+	lines := strings.Split(strings.Join(rows[first:last], "\n"), "\n")
+	if msg := styleStatusMessage(m.statusMessage); msg != "" {
+		lines = append(lines, msg)
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return mainContentStyle.Render(strings.Join(lines[:height], "\n"))
+}
+
+func (m Model) gridVisibleRows(height int) int {
+	return max(height/(cardHeight+2), 1)
+}
+
+// gridFirstRow scrolls whole rows so the selected card stays visible
+func (m Model) gridFirstRow(height int) int {
+	visibleRows := m.gridVisibleRows(height)
+	if selectedRow := m.activeIdx / m.gridColumns(); selectedRow >= visibleRows {
+		return selectedRow - visibleRows + 1
+	}
+	return 0
+}
+
+func (m Model) renderCard(idx int) string {
+	ws := m.workspaces[idx]
+	status := m.cardStatus[ws.Path]
+
+	title := fmt.Sprintf("%d %s", idx+1, ws.Name)
+	if !ws.IsSubWorkspace {
+		title += " (main)"
+	}
+
+	git := mutedStyle.Render("clean")
+	if ws.IsDirty {
+		git = statusMsgStyle.Render("dirty")
+	}
+	if ws.Ahead > 0 || ws.Behind > 0 {
+		git += mutedStyle.Render(fmt.Sprintf(" ↑%d↓%d", ws.Ahead, ws.Behind))
+	}
+
+	session := mutedStyle.Render("○ no session")
+	if status.session {
+		session = valueStyle.Render(fmt.Sprintf("● %d tab(s)", status.tabs))
+	}
+
+	agents := mutedStyle.Render("no agents")
+	if len(status.agents) > 0 {
+		var parts []string
+		for _, agent := range status.agents {
+			if agent.running {
+				parts = append(parts, successStyle.Render(agent.name))
+			} else {
+				parts = append(parts, mutedStyle.Render(agent.name+" idle"))
+			}
+		}
+		agents = strings.Join(parts, mutedStyle.Render(", "))
+	}
+
+	commit := mutedStyle.Render("no commits")
+	if len(ws.RecentCommits) > 0 {
+		hash, msg := splitCommitLine(ws.RecentCommits[0])
+		commit = commitHashStyle.Render(hash) + " " + mutedStyle.Render(truncateText(msg, cardWidth-len(hash)-1))
+	}
+
+	lines := []string{
+		projectNameStyle.Render(truncateText(title, cardWidth)),
+		valueStyle.Render(truncateText(ws.Branch, cardWidth-8)) + "  " + git,
+		session,
+		agents,
+		commit,
+	}
+	for i, line := range lines {
+		if lipgloss.Width(line) > cardWidth {
+			lines[i] = lipgloss.NewStyle().MaxWidth(cardWidth).Render(line)
+		}
+	}
+
+	style := cardStyle
+	if idx == m.activeIdx {
+		style = cardSelectedStyle
+	}
+	return style.Render(strings.Join(lines, "\n"))
+}
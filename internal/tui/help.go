@@ -0,0 +1,147 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/emilianotisato/vibeit/internal/config"
+)
+
+// helpSection is a group of keybindings shown together
+type helpSection struct {
+	title   string
+	entries [][2]string // key, description
+}
+
+// helpSections lists every keybinding by context, using the active keymap
+// and the tmux keys from settings.
+func (k keyMap) helpSections(settings config.Settings) []helpSection {
+	main := helpSection{title: "Main view", entries: bindingHelp(struct{ Number key.Binding }{workspaceNumberKeys})}
+	for _, a := range keyActions {
+		help := a.field(&k).Help()
+		main.entries = append(main.entries, [2]string{help.Key, help.Desc})
+	}
+
+	tmux := helpSection{title: "Inside tmux"}
+	for _, b := range []struct{ key, desc string }{
+		{settings.Tmux.DetachKey, "back to vibeit (detach, or switch inside tmux)"},
+		{settings.Tmux.LastWindowKey, "previous tab"},
+		{settings.Tmux.OverviewKey, "toggle overview grid"},
+	} {
+		if b.key == "" || b.key == "off" || b.key == "none" {
+			continue
+		}
+		tmux.entries = append(tmux.entries, [2]string{b.key, b.desc})
+	}
+
+	return []helpSection{
+		main,
+		{title: "Workspace grid", entries: bindingHelp(gridKeys)},
+		{title: "Tab picker", entries: bindingHelp(tabPickerKeys)},
+		{title: "New tab type", entries: bindingHelp(tabTypePickerKeys)},
+		{title: "Notes picker", entries: bindingHelp(notesPickerKeys)},
+		{title: "Notes todos", entries: bindingHelp(todosKeys)},
+		{title: "Notes search", entries: bindingHelp(notesSearchKeys)},
+		{title: "Notes history", entries: bindingHelp(historyKeys)},
+		{title: "Send notes", entries: bindingHelp(sendNotesKeys)},
+		{title: "Command palette", entries: bindingHelp(paletteKeys)},
+		{title: "Markdown finder", entries: bindingHelp(mdLuncherKeys)},
+		{title: "New workspace", entries: bindingHelp(newWorkspaceKeys)},
+		{title: "Confirmations", entries: bindingHelp(confirmKeys)},
+		{title: "Markdown viewer", entries: bindingHelp(markdownKeys)},
+		{title: "Markdown search", entries: bindingHelp(markdownSearchKeys)},
+		{title: "Help", entries: bindingHelp(helpOverlayKeys)},
+		tmux,
+	}
+}
+
+// formatHelp renders sections as aligned plain-text lines
+func formatHelp(sections []helpSection) []string {
+	width := 0
+	for _, section := range sections {
+		for _, entry := range section.entries {
+			width = max(width, len([]rune(entry[0])))
+		}
+	}
+
+	var lines []string
+	for i, section := range sections {
+		if len(section.entries) == 0 {
+			continue
+		}
+		if i > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, section.title)
+		for _, entry := range section.entries {
+			pad := strings.Repeat(" ", width-len([]rune(entry[0])))
+			lines = append(lines, fmt.Sprintf("  %s%s  %s", entry[0], pad, entry[1]))
+		}
+	}
+	return lines
+}
+
+// KeyHelp returns every keybinding grouped by context, one line per entry
+func KeyHelp(settings config.Settings) ([]string, error) {
+	km, err := newKeyMap(settings.Keys)
+	if err != nil {
+		return nil, err
+	}
+	return formatHelp(km.helpSections(settings)), nil
+}
+
+func (m Model) openHelp() (tea.Model, tea.Cmd) {
+	m.helpScroll = 0
+	m.modal = modalHelp
+	return m, nil
+}
+
+// helpVisibleLines is how many help lines fit in the overlay
+func (m Model) helpVisibleLines() int {
+	return max(m.height-12, 5)
+}
+
+func (m Model) handleHelpInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	total := len(formatHelp(keys.helpSections(m.settings)))
+
+	switch {
+	case key.Matches(msg, helpOverlayKeys.Close):
+		m.modal = modalNone
+	case key.Matches(msg, helpOverlayKeys.Up):
+		m.helpScroll, _ = scrollWindow(total, m.helpScroll-1, m.helpVisibleLines())
+	case key.Matches(msg, helpOverlayKeys.Down):
+		m.helpScroll, _ = scrollWindow(total, m.helpScroll+1, m.helpVisibleLines())
+	}
+	return m, nil
+}
+
+func (m Model) renderHelpModal() string {
+	lines := formatHelp(keys.helpSections(m.settings))
+	start, end := scrollWindow(len(lines), m.helpScroll, m.helpVisibleLines())
+
+	titles := map[string]bool{}
+	for _, section := range keys.helpSections(m.settings) {
+		titles[section.title] = true
+	}
+
+	var content strings.Builder
+	content.WriteString(modalTitleStyle.Render("Keybindings"))
+	content.WriteString("\n\n")
+	for _, line := range lines[start:end] {
+		if titles[line] {
+			content.WriteString(sectionTitleStyle.Render(strings.ToUpper(line)))
+		} else {
+			content.WriteString(modalItemStyle.Render(truncateText(line, 44)))
+		}
+		content.WriteString("\n")
+	}
+
+	hint := "Esc to close"
+	if end-start < len(lines) {
+		hint = fmt.Sprintf("%d-%d of %d • ↑/↓ to scroll • Esc to close", start+1, end, len(lines))
+	}
+	content.WriteString(modalHintStyle.Render(hint))
+	return modalStyle.Render(content.String())
+}
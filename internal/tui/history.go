@@ -0,0 +1,187 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/emilianotisato/vibeit/internal/notes"
+)
+
+const (
+	historyVisible     = 6
+	historyDiffVisible = 14
+	historyContext     = 2
+)
+
+// openHistory lists the snapshots of a notes file
+func (m Model) openHistory(path, title string) (tea.Model, tea.Cmd) {
+	// Make sure the latest content is in the history before browsing it
+	if err := notes.Snapshot(path); err != nil {
+		m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to snapshot notes: %v", err))
+		return m, nil
+	}
+	versions, err := notes.History(path)
+	if err != nil {
+		m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to read notes history: %v", err))
+		return m, nil
+	}
+	if len(versions) == 0 {
+		m.modal = modalNone
+		m.statusMessage = mutedStyle.Render("No history yet for " + title)
+		return m, nil
+	}
+
+	m.modal = modalHistory
+	m.historyPath = path
+	m.historyTitle = title
+	m.historyVersions = versions
+	m.historyIdx = 0
+	m.historyError = ""
+	m.loadHistoryDiff()
+	return m, nil
+}
+
+// loadHistoryDiff diffs the current notes against the selected snapshot,
+// i.e. what restoring it would change
+func (m *Model) loadHistoryDiff() {
+	m.historyScroll = 0
+	m.historyDiff = nil
+
+	current, err := os.ReadFile(m.historyPath)
+	if err != nil && !os.IsNotExist(err) {
+		m.historyError = err.Error()
+		return
+	}
+	version, err := os.ReadFile(m.historyVersions[m.historyIdx].Path)
+	if err != nil {
+		m.historyError = err.Error()
+		return
+	}
+	m.historyDiff = notes.Diff(string(current), string(version), historyContext)
+}
+
+func (m Model) handleHistoryInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, historyKeys.Close):
+		m.modal = modalNone
+		return m, nil
+
+	case key.Matches(msg, historyKeys.Up):
+		if m.historyIdx > 0 {
+			m.historyIdx--
+			m.loadHistoryDiff()
+		}
+		return m, nil
+
+	case key.Matches(msg, historyKeys.Down):
+		if m.historyIdx < len(m.historyVersions)-1 {
+			m.historyIdx++
+			m.loadHistoryDiff()
+		}
+		return m, nil
+
+	case key.Matches(msg, historyKeys.ScrollUp):
+		m.historyScroll = max(m.historyScroll-historyDiffVisible/2, 0)
+		return m, nil
+
+	case key.Matches(msg, historyKeys.ScrollDown):
+		m.historyScroll, _ = scrollWindow(len(m.historyDiff), m.historyScroll+historyDiffVisible/2, historyDiffVisible)
+		return m, nil
+
+	case key.Matches(msg, historyKeys.Restore):
+		version := m.historyVersions[m.historyIdx]
+		if len(m.historyDiff) == 0 {
+			m.historyError = "Notes already match this version"
+			return m, nil
+		}
+		if err := notes.Restore(m.historyPath, version); err != nil {
+			m.historyError = err.Error()
+			return m, nil
+		}
+		m.modal = modalNone
+		m.statusMessage = successStyle.Render(fmt.Sprintf("Restored %s from %s", m.historyTitle, version.Time.Format("2006-01-02 15:04:05")))
+		return m, refreshGitStatus(m.workspaces, m.projectPath, m.projectName)
+	}
+
+	return m, nil
+}
+
+func (m Model) renderHistoryModal() string {
+	width := 64
+
+	var content strings.Builder
+	content.WriteString(modalTitleStyle.Render("Notes history: " + truncateText(m.historyTitle, 40)))
+	content.WriteString("\n\n")
+
+	start, end := scrollWindow(len(m.historyVersions), m.historyIdx-historyVisible/2, historyVisible)
+	for i := start; i < end; i++ {
+		version := m.historyVersions[i]
+		meta := timeAgo(version.Time)
+		if i == 0 {
+			meta += " · latest"
+		}
+		prefix, style := "  ", modalItemStyle
+		if i == m.historyIdx {
+			prefix, style = "> ", modalItemSelectedStyle
+		}
+		content.WriteString(style.Render(prefix + version.Time.Format("2006-01-02 15:04:05")))
+		content.WriteString("  " + mutedStyle.Render(meta))
+		content.WriteString("\n")
+	}
+	if len(m.historyVersions) > historyVisible {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  %d/%d", m.historyIdx+1, len(m.historyVersions))))
+		content.WriteString("\n")
+	}
+
+	content.WriteString("\n")
+	content.WriteString(labelStyle.Render("Restoring this version changes:"))
+	content.WriteString("\n")
+	if len(m.historyDiff) == 0 {
+		content.WriteString(mutedStyle.Render("  nothing, notes match this version"))
+		content.WriteString("\n")
+	}
+	diffStart, diffEnd := scrollWindow(len(m.historyDiff), m.historyScroll, historyDiffVisible)
+	for _, line := range m.historyDiff[diffStart:diffEnd] {
+		op := string(line.Op)
+		if line.Op == '~' {
+			op = " "
+		}
+		text := truncateText(op+" "+line.Text, width)
+		switch line.Op {
+		case '+':
+			content.WriteString(successStyle.Render(text))
+		case '-':
+			content.WriteString(errorStyle.Render(text))
+		default:
+			content.WriteString(mutedStyle.Render(text))
+		}
+		content.WriteString("\n")
+	}
+
+	if m.historyError != "" {
+		content.WriteString(errorStyle.Render(m.historyError))
+		content.WriteString("\n")
+	}
+
+	content.WriteString(modalHintStyle.Render("r restore • J/K scroll diff • Esc to close"))
+	return modalStyle.Render(content.String())
+}
+
+// timeAgo formats how long ago t was, e.g. "5m ago"
+func timeAgo(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
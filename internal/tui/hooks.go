@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/emilianotisato/vibeit/internal/hooks"
+	"github.com/emilianotisato/vibeit/internal/mux"
+	"github.com/emilianotisato/vibeit/internal/workspace"
+	workspace_init "github.com/emilianotisato/vibeit/internal/workspace_init"
+)
+
+type hookFinishedMsg struct {
+	err error
+}
+
+// fireHook runs the commands configured for an event in the background
+func (m Model) fireHook(payload hooks.Payload) tea.Cmd {
+	projectPath := m.projectPath
+	return func() tea.Msg {
+		wtConfig, err := workspace_init.LoadConfig(projectPath)
+		if err != nil {
+			return hookFinishedMsg{err: err}
+		}
+		commands := wtConfig.Hooks[string(payload.Event)]
+		if len(commands) == 0 {
+			return nil
+		}
+		return hookFinishedMsg{err: hooks.Run(commands, payload)}
+	}
+}
+
+// detachHooks starts the hooks for payloads in a separate process, for a
+// dashboard that exits before they could finish
+func (m Model) detachHooks(payloads []hooks.Payload) tea.Cmd {
+	if len(payloads) == 0 {
+		return nil
+	}
+	projectPath := m.projectPath
+	return func() tea.Msg {
+		wtConfig, err := workspace_init.LoadConfig(projectPath)
+		if err != nil {
+			return hookFinishedMsg{err: err}
+		}
+		for _, payload := range payloads {
+			if err := hooks.RunDetached(vibeitExecutable(), wtConfig.Hooks[string(payload.Event)], payload); err != nil {
+				return hookFinishedMsg{err: err}
+			}
+		}
+		return nil
+	}
+}
+
+func (m Model) hookPayload(ws workspace.Workspace, event hooks.Event) hooks.Payload {
+	return hooks.Payload{
+		Event:     event,
+		Project:   m.projectName,
+		Workspace: ws.Name,
+		Path:      ws.Path,
+		Branch:    ws.Branch,
+		Session:   mux.SessionName(m.projectName, ws.Name, ws.Branch),
+	}
+}
+
+func (m Model) activeHook(event hooks.Event) tea.Cmd {
+	return m.fireHook(m.hookPayload(m.workspaces[m.activeIdx], event))
+}
+
+// activate switches the active workspace and fires workspace_activated
+func (m Model) activate(idx int) (tea.Model, tea.Cmd) {
+	if idx == m.activeIdx {
+		return m, nil
+	}
+	m.activeIdx = idx
+	m.commitScroll, m.notesScroll = 0, 0
+	return m, m.activeHook(hooks.WorkspaceActivated)
+}
+
+// sessionCmd runs a tmux command for a workspace and fires the session_created
+// and agent_started hooks it triggers
+func (m Model) sessionCmd(cmd *exec.Cmd, ws workspace.Workspace, tabName string, tabType mux.TabType) tea.Cmd {
+	var payloads []hooks.Payload
+	var logCmd tea.Cmd
+
+	payload := m.hookPayload(ws, hooks.SessionCreated)
+	payload.Tab = tabName
+	if !mux.SessionExists(payload.Session) {
+		payloads = append(payloads, payload)
+	}
+
+	if tabType == mux.TabClaude || tabType == mux.TabCodex {
+		payload.Event = hooks.AgentStarted
+		payload.Agent = string(tabType)
+		payloads = append(payloads, payload)
+		logCmd = m.logSession(ws, "started "+tabName)
+	}
+
+	if mux.QuitAfterAttach() {
+		// A popup dashboard closes right after the client switched; its hooks
+		// run in their own process so they can't hold the popup open
+		return tea.Sequence(runExternalCmd(cmd), logCmd, m.detachHooks(payloads), tea.Quit)
+	}
+
+	cmds := []tea.Cmd{runExternalCmd(cmd), logCmd}
+	for _, payload := range payloads {
+		cmds = append(cmds, m.fireHook(payload))
+	}
+	return tea.Batch(cmds...)
+}
+
+// gitStatusEvents compares a poll with the previous one and returns git_status_changed
+// payloads for workspaces that became dirty/clean or moved HEAD. HEAD moving forward
+// on the same branch is a commit; a branch switch, reset or rebase is a head change.
+func (m Model) gitStatusEvents(updated []workspace.Workspace) []hooks.Payload {
+	var events []hooks.Payload
+	for _, ws := range updated {
+		before, ok := m.gitSnapshot[ws.Path]
+		if !ok {
+			continue
+		}
+
+		if before.IsDirty != ws.IsDirty {
+			payload := m.hookPayload(ws, hooks.GitStatusChanged)
+			payload.Change = hooks.ChangeClean
+			if ws.IsDirty {
+				payload.Change = hooks.ChangeDirty
+			}
+			events = append(events, payload)
+		}
+
+		if ws.Head != "" && before.Head != ws.Head {
+			payload := m.hookPayload(ws, hooks.GitStatusChanged)
+			payload.Change = hooks.ChangeHead
+			if before.Head != "" && before.Branch == ws.Branch && workspace.IsAncestor(ws.Path, before.Head, ws.Head) {
+				payload.Change = hooks.ChangeCommit
+			}
+			payload.Commit = ws.Head
+			if len(ws.RecentCommits) > 0 {
+				_, payload.Subject = splitCommitLine(ws.RecentCommits[0])
+			}
+			events = append(events, payload)
+		}
+	}
+	return events
+}
+
+func gitSnapshot(workspaces []workspace.Workspace) map[string]workspace.Workspace {
+	snapshot := make(map[string]workspace.Workspace, len(workspaces))
+	for _, ws := range workspaces {
+		snapshot[ws.Path] = ws
+	}
+	return snapshot
+}
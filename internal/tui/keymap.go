@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type keyMap struct {
+	Quit        key.Binding
+	NextTab     key.Binding
+	PrevTab     key.Binding
+	Terminal    key.Binding
+	Git         key.Binding
+	Claude      key.Binding
+	Codex       key.Binding
+	Neovim      key.Binding
+	Notes       key.Binding
+	Config      key.Binding
+	InitConfig  key.Binding
+	Workspace   key.Binding
+	KillSession key.Binding
+	Remove      key.Binding
+	Enter       key.Binding
+	MdLuncher   key.Binding
+	Palette     key.Binding
+	Grid        key.Binding
+	Help        key.Binding
+	ReadNotes   key.Binding
+	NotesPicker key.Binding
+	Todos       key.Binding
+	History     key.Binding
+	SendNotes   key.Binding
+}
+
+// keyAction describes a remappable main-view action. The id is the name used
+// under "keys" in the vibeit config; footer is the short label shown in the
+// footer, or empty to keep the action out of it.
+type keyAction struct {
+	id     string
+	keys   []string
+	help   string
+	footer string
+	field  func(*keyMap) *key.Binding
+}
+
+// keyActions lists main-view actions in footer/help order
+var keyActions = []keyAction{
+	{"palette", []string{":", "ctrl+p"}, "command palette", "cmds", func(k *keyMap) *key.Binding { return &k.Palette }},
+	{"lazygit", []string{"g"}, "open lazygit", "lazygit", func(k *keyMap) *key.Binding { return &k.Git }},
+	{"claude", []string{"c"}, "claude tabs", "claude", func(k *keyMap) *key.Binding { return &k.Claude }},
+	{"codex", []string{"x"}, "codex tabs", "codex", func(k *keyMap) *key.Binding { return &k.Codex }},
+	{"nvim", []string{"v"}, "nvim tabs", "nvim", func(k *keyMap) *key.Binding { return &k.Neovim }},
+	{"terminal", []string{"t"}, "terminal tabs", "term", func(k *keyMap) *key.Binding { return &k.Terminal }},
+	{"notes", []string{"n"}, "open notes", "notes", func(k *keyMap) *key.Binding { return &k.Notes }},
+	{"read_notes", []string{"r"}, "read notes", "", func(k *keyMap) *key.Binding { return &k.ReadNotes }},
+	{"notes_picker", []string{"N"}, "pick notes (branch or shared)", "", func(k *keyMap) *key.Binding { return &k.NotesPicker }},
+	{"todos", []string{"T"}, "notes todos and search", "", func(k *keyMap) *key.Binding { return &k.Todos }},
+	{"notes_history", []string{"H"}, "notes history", "", func(k *keyMap) *key.Binding { return &k.History }},
+	{"send_notes", []string{"s"}, "send notes to an agent tab", "", func(k *keyMap) *key.Binding { return &k.SendNotes }},
+	{"open_md", []string{"o"}, "open markdown file", "open md", func(k *keyMap) *key.Binding { return &k.MdLuncher }},
+	{"edit_config", []string{"e"}, "edit .vibe/wt.json", "wt.json", func(k *keyMap) *key.Binding { return &k.Config }},
+	{"init_config", []string{"i"}, "propose .vibe/wt.json", "", func(k *keyMap) *key.Binding { return &k.InitConfig }},
+	{"new_workspace", []string{"w"}, "new workspace", "new ws", func(k *keyMap) *key.Binding { return &k.Workspace }},
+	{"kill_session", []string{"k"}, "kill tmux session", "kill ses", func(k *keyMap) *key.Binding { return &k.KillSession }},
+	{"remove_workspace", []string{"D"}, "remove workspace", "rm ws", func(k *keyMap) *key.Binding { return &k.Remove }},
+	{"grid", []string{"m"}, "toggle workspace grid", "grid", func(k *keyMap) *key.Binding { return &k.Grid }},
+	{"tabs", []string{"enter"}, "show all tabs", "tabs", func(k *keyMap) *key.Binding { return &k.Enter }},
+	{"next_workspace", []string{"tab", "l"}, "next workspace", "", func(k *keyMap) *key.Binding { return &k.NextTab }},
+	{"prev_workspace", []string{"shift+tab", "h"}, "previous workspace", "", func(k *keyMap) *key.Binding { return &k.PrevTab }},
+	{"help", []string{"?"}, "show keybindings", "help", func(k *keyMap) *key.Binding { return &k.Help }},
+	{"quit", []string{"q", "ctrl+c"}, "quit", "quit", func(k *keyMap) *key.Binding { return &k.Quit }},
+}
+
+// reservedKeys are handled outside the keymap and can't be rebound
+var reservedKeys = []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}
+
+var keys = mustKeyMap(nil)
+
+// newKeyMap builds the keymap from defaults and config overrides, rejecting
+// unknown actions and keys bound to more than one action.
+func newKeyMap(overrides map[string][]string) (keyMap, error) {
+	var km keyMap
+
+	known := make(map[string]bool, len(keyActions))
+	for _, a := range keyActions {
+		known[a.id] = true
+	}
+	for id := range overrides {
+		if !known[id] {
+			return km, fmt.Errorf("keys: unknown action %q", id)
+		}
+	}
+
+	owner := map[string]string{}
+	for _, k := range reservedKeys {
+		owner[k] = "switch workspace"
+	}
+
+	var conflicts []string
+	for _, a := range keyActions {
+		bound := a.keys
+		if custom, ok := overrides[a.id]; ok {
+			bound = custom
+		}
+		for _, k := range bound {
+			if other, taken := owner[k]; taken {
+				conflicts = append(conflicts, fmt.Sprintf("%q is bound to both %s and %s", k, other, a.id))
+				continue
+			}
+			owner[k] = a.id
+		}
+
+		binding := key.NewBinding(key.WithKeys(bound...), key.WithHelp(keyLabel(bound), a.help))
+		if len(bound) == 0 {
+			binding.SetEnabled(false)
+		}
+		*a.field(&km) = binding
+	}
+
+	if len(conflicts) > 0 {
+		sort.Strings(conflicts)
+		return km, fmt.Errorf("keybinding conflicts:\n  %s", strings.Join(conflicts, "\n  "))
+	}
+	return km, nil
+}
+
+func mustKeyMap(overrides map[string][]string) keyMap {
+	km, err := newKeyMap(overrides)
+	if err != nil {
+		panic(err)
+	}
+	return km
+}
+
+// keyLabel formats bound keys for display, e.g. ["shift+tab", "h"] -> "S-tab/h"
+func keyLabel(bound []string) string {
+	if len(bound) == 0 {
+		return "(unbound)"
+	}
+	labels := make([]string, len(bound))
+	for i, k := range bound {
+		k = strings.Replace(k, "shift+", "S-", 1)
+		k = strings.Replace(k, "ctrl+", "C-", 1)
+		k = strings.Replace(k, "alt+", "M-", 1)
+		labels[i] = k
+	}
+	return strings.Join(labels, "/")
+}
+
+// footerBinding is a footer entry: the action id, its primary key and label
+type footerBinding struct {
+	id    string
+	key   string
+	label string
+}
+
+// footerBindings returns the footer entries for the active keymap
+func (k keyMap) footerBindings() []footerBinding {
+	var bindings []footerBinding
+	for _, a := range keyActions {
+		if a.footer == "" {
+			continue
+		}
+		binding := a.field(&k)
+		if !binding.Enabled() {
+			continue
+		}
+		// Footer shows only the primary key
+		bindings = append(bindings, footerBinding{a.id, keyLabel(binding.Keys()[:1]), a.footer})
+	}
+	return bindings
+}
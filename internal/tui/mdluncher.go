@@ -0,0 +1,228 @@
+package tui
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/emilianotisato/vibeit/internal/fuzzy"
+	"github.com/emilianotisato/vibeit/internal/state"
+)
+
+const mdLuncherVisible = 10
+
+// skipDirs are never descended into when git can't list files
+var skipDirs = map[string]bool{".git": true, "node_modules": true, "vendor": true}
+
+type mdFilesMsg struct {
+	files []string
+	err   error
+}
+
+func (m Model) openMdLuncher() (tea.Model, tea.Cmd) {
+	ws := m.workspaces[m.activeIdx]
+	roots := m.settings.MdRoots
+	projectPath := m.projectPath
+
+	m.modal = modalMdLuncher
+	m.mdLuncherFiles = nil
+	m.mdLuncherMatches = nil
+	m.mdLuncherIdx = 0
+	m.mdLuncherError = ""
+	m.mdLuncherScanning = true
+	m.mdLuncherInput.SetValue("")
+	m.mdLuncherInput.Focus()
+
+	scan := func() tea.Msg {
+		files, err := listMarkdownFiles(ws.Path, roots)
+		return mdFilesMsg{files: recentFirst(files, state.RecentFiles(projectPath)), err: err}
+	}
+	return m, tea.Batch(textinput.Blink, scan)
+}
+
+// listMarkdownFiles returns workspace-relative .md paths under roots (the
+// whole workspace when empty). git ls-files honours .gitignore; outside a
+// git checkout the tree is walked, skipping .git, node_modules and vendor.
+func listMarkdownFiles(wsPath string, roots []string) ([]string, error) {
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+
+	args := append([]string{"ls-files", "--cached", "--others", "--exclude-standard", "-z", "--"}, roots...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = wsPath
+	if out, err := cmd.Output(); err == nil {
+		var files []string
+		for _, file := range strings.Split(string(out), "\x00") {
+			if !isMarkdown(file) {
+				continue
+			}
+			// Tracked files deleted in the working tree are still listed
+			if _, err := os.Stat(filepath.Join(wsPath, file)); err == nil {
+				files = append(files, file)
+			}
+		}
+		sort.Strings(files)
+		return files, nil
+	}
+
+	var files []string
+	for _, root := range roots {
+		err := filepath.WalkDir(filepath.Join(wsPath, root), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			if d.IsDir() && skipDirs[d.Name()] {
+				return filepath.SkipDir
+			}
+			if !d.IsDir() && isMarkdown(d.Name()) {
+				if rel, err := filepath.Rel(wsPath, path); err == nil {
+					files = append(files, rel)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+func isMarkdown(name string) bool {
+	return strings.HasSuffix(strings.ToLower(name), ".md")
+}
+
+// recentFirst moves recently opened files to the front, most recent first
+func recentFirst(files, recent []string) []string {
+	present := make(map[string]bool, len(files))
+	for _, f := range files {
+		present[f] = true
+	}
+
+	ordered := make([]string, 0, len(files))
+	seen := map[string]bool{}
+	for _, f := range recent {
+		if present[f] {
+			ordered = append(ordered, f)
+			seen[f] = true
+		}
+	}
+	for _, f := range files {
+		if !seen[f] {
+			ordered = append(ordered, f)
+		}
+	}
+	return ordered
+}
+
+func (m *Model) updateMdLuncherMatches() {
+	m.mdLuncherMatches = fuzzy.Filter(m.mdLuncherInput.Value(), m.mdLuncherFiles)
+	m.mdLuncherIdx = 0
+}
+
+func (m Model) handleMdLuncherInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, mdLuncherKeys.Close):
+		m.modal = modalNone
+		m.mdLuncherInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, mdLuncherKeys.Up):
+		if m.mdLuncherIdx > 0 {
+			m.mdLuncherIdx--
+		}
+		return m, nil
+
+	case key.Matches(msg, mdLuncherKeys.Down):
+		if m.mdLuncherIdx < len(m.mdLuncherMatches)-1 {
+			m.mdLuncherIdx++
+		}
+		return m, nil
+
+	case key.Matches(msg, mdLuncherKeys.Open):
+		if len(m.mdLuncherMatches) == 0 {
+			return m, nil
+		}
+		rel := m.mdLuncherFiles[m.mdLuncherMatches[m.mdLuncherIdx].Index]
+		m.modal = modalNone
+		m.mdLuncherInput.Blur()
+		if err := state.AddRecentFile(m.projectPath, rel); err != nil {
+			m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to save recent files: %v", err))
+		}
+		return m.openMarkdown(filepath.Join(m.workspaces[m.activeIdx].Path, rel))
+	}
+
+	var cmd tea.Cmd
+	m.mdLuncherInput, cmd = m.mdLuncherInput.Update(msg)
+	m.updateMdLuncherMatches()
+	return m, cmd
+}
+
+func (m Model) renderMdLuncherModal() string {
+	width := 44
+
+	var content strings.Builder
+	title := "Open Markdown"
+	if !m.mdLuncherScanning {
+		title = fmt.Sprintf("Open Markdown (%d/%d)", len(m.mdLuncherMatches), len(m.mdLuncherFiles))
+	}
+	content.WriteString(modalTitleStyle.Render(title))
+	content.WriteString("\n\n")
+	content.WriteString(m.mdLuncherInput.View())
+	content.WriteString("\n\n")
+
+	switch {
+	case m.mdLuncherScanning:
+		content.WriteString(mutedStyle.Render("  scanning..."))
+		content.WriteString("\n")
+	case m.mdLuncherError != "":
+		content.WriteString(errorStyle.Render(m.mdLuncherError))
+		content.WriteString("\n")
+	case len(m.mdLuncherMatches) == 0:
+		content.WriteString(mutedStyle.Render("  no markdown files match"))
+		content.WriteString("\n")
+	}
+
+	start, end := scrollWindow(len(m.mdLuncherMatches), m.mdLuncherIdx-mdLuncherVisible+1, mdLuncherVisible)
+	for i := start; i < end; i++ {
+		match := m.mdLuncherMatches[i]
+		path := m.mdLuncherFiles[match.Index]
+
+		base, highlight := modalItemStyle, matchStyle
+		prefix := "  "
+		if i == m.mdLuncherIdx {
+			base, highlight = modalItemSelectedStyle, modalItemSelectedStyle.Underline(true)
+			prefix = "> "
+		}
+
+		// Keep the end of long paths, where the file name is
+		positions := match.Positions
+		if runes := []rune(path); len(runes) > width-2 {
+			cut := len(runes) - (width - 5)
+			path = "..." + string(runes[cut:])
+			var shifted []int
+			for _, pos := range positions {
+				if pos >= cut {
+					shifted = append(shifted, pos-cut+3)
+				}
+			}
+			positions = shifted
+		}
+
+		content.WriteString(base.Render(prefix))
+		content.WriteString(highlightMatches(path, positions, base, highlight))
+		content.WriteString("\n")
+	}
+
+	content.WriteString(modalHintStyle.Render("Type to filter • Enter to open • Esc to cancel"))
+	return modalStyle.Render(content.String())
+}
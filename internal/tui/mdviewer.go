@@ -0,0 +1,326 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/emilianotisato/vibeit/internal/mux"
+)
+
+// markdownStyle is the glamour style matching the active theme
+var markdownStyle = "dark"
+
+// mdViewer is the state of the in-TUI markdown viewer
+type mdViewer struct {
+	path     string
+	source   string
+	lines    []string // rendered lines
+	plain    []string // rendered lines without ANSI, for search
+	headings []int    // line index of each heading
+	viewport viewport.Model
+
+	searchInput textinput.Model
+	searching   bool
+	query       string
+	matches     []int
+	matchIdx    int
+}
+
+type markdownLoadedMsg struct {
+	viewer mdViewer
+	err    error
+}
+
+// openMarkdown renders a markdown file and shows it in the viewer
+func (m Model) openMarkdown(path string) (tea.Model, tea.Cmd) {
+	width, height := m.markdownViewSize()
+	return m, func() tea.Msg {
+		viewer, err := loadMarkdown(path, width, height)
+		return markdownLoadedMsg{viewer: viewer, err: err}
+	}
+}
+
+// markdownViewSize is the viewport size: the screen minus top bar and status line
+func (m Model) markdownViewSize() (int, int) {
+	return max(m.width, 20), max(m.height-2, 5)
+}
+
+func loadMarkdown(path string, width, height int) (mdViewer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return mdViewer{}, err
+	}
+
+	searchInput := textinput.New()
+	searchInput.Prompt = "/"
+	searchInput.CharLimit = 100
+
+	v := mdViewer{
+		path:        path,
+		source:      string(data),
+		viewport:    viewport.New(width, height),
+		searchInput: searchInput,
+	}
+	if err := v.render(width); err != nil {
+		return v, err
+	}
+	return v, nil
+}
+
+// render lays the document out for width and locates headings in the output
+func (v *mdViewer) render(width int) error {
+	renderer, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(markdownStyle),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+		glamour.WithWordWrap(max(width-4, 20)),
+	)
+	if err != nil {
+		return err
+	}
+	out, err := renderer.Render(v.source)
+	if err != nil {
+		return err
+	}
+
+	v.lines = strings.Split(strings.TrimRight(out, "\n"), "\n")
+	v.plain = make([]string, len(v.lines))
+	for i, line := range v.lines {
+		v.plain[i] = strings.TrimSpace(ansi.Strip(line))
+	}
+
+	// Glamour drops the markup, so find each source heading's text in order
+	v.headings = nil
+	next := 0
+	for _, heading := range markdownHeadings(v.source) {
+		for i := next; i < len(v.plain); i++ {
+			if strings.Contains(v.plain[i], heading) {
+				v.headings = append(v.headings, i)
+				next = i + 1
+				break
+			}
+		}
+	}
+
+	v.viewport.Width = width
+	v.search(v.query)
+	return nil
+}
+
+// markdownHeadings returns the text of ATX headings outside code fences
+func markdownHeadings(source string) []string {
+	var headings []string
+	inFence := false
+	for _, line := range strings.Split(source, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		rest := strings.TrimLeft(trimmed, "#")
+		level := len(trimmed) - len(rest)
+		if level < 1 || level > 6 || !strings.HasPrefix(rest, " ") {
+			continue
+		}
+		if text := strings.TrimSpace(strings.TrimRight(rest, "# ")); text != "" {
+			headings = append(headings, text)
+		}
+	}
+	return headings
+}
+
+// search marks every line containing query, case-insensitively
+func (v *mdViewer) search(query string) {
+	v.query = query
+	v.matches = nil
+	v.matchIdx = 0
+	if query != "" {
+		needle := strings.ToLower(query)
+		for i, line := range v.plain {
+			if strings.Contains(strings.ToLower(line), needle) {
+				v.matches = append(v.matches, i)
+			}
+		}
+	}
+	v.refresh()
+}
+
+// refresh rebuilds the viewport content with a gutter marking search matches
+func (v *mdViewer) refresh() {
+	marked := make(map[int]bool, len(v.matches))
+	for _, line := range v.matches {
+		marked[line] = true
+	}
+	current := -1
+	if len(v.matches) > 0 {
+		current = v.matches[v.matchIdx]
+	}
+
+	var content strings.Builder
+	for i, line := range v.lines {
+		switch {
+		case i == current:
+			content.WriteString(statusMsgStyle.Render("▶"))
+		case marked[i]:
+			content.WriteString(matchStyle.Render("•"))
+		default:
+			content.WriteString(" ")
+		}
+		content.WriteString(line)
+		if i < len(v.lines)-1 {
+			content.WriteString("\n")
+		}
+	}
+	v.viewport.SetContent(content.String())
+}
+
+// jump scrolls so line sits near the top of the viewport
+func (v *mdViewer) jump(line int) {
+	v.viewport.SetYOffset(max(line-2, 0))
+}
+
+// nextHeading returns the first heading below (dir 1) or above (dir -1) the top line
+func (v *mdViewer) nextHeading(dir int) (int, bool) {
+	top := v.viewport.YOffset + 2
+	if dir > 0 {
+		for _, line := range v.headings {
+			if line > top {
+				return line, true
+			}
+		}
+		return 0, false
+	}
+	for i := len(v.headings) - 1; i >= 0; i-- {
+		if v.headings[i] < top {
+			return v.headings[i], true
+		}
+	}
+	return 0, false
+}
+
+func (m Model) handleMarkdownInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := &m.mdViewer
+
+	if v.searching {
+		switch {
+		case key.Matches(msg, markdownSearchKeys.Cancel):
+			v.searching = false
+			v.searchInput.Blur()
+			return m, nil
+		case key.Matches(msg, markdownSearchKeys.Apply):
+			v.searching = false
+			v.searchInput.Blur()
+			v.search(v.searchInput.Value())
+			if len(v.matches) > 0 {
+				v.jump(v.matches[0])
+			}
+			return m, nil
+		}
+		var cmd tea.Cmd
+		v.searchInput, cmd = v.searchInput.Update(msg)
+		return m, cmd
+	}
+
+	switch {
+	case key.Matches(msg, markdownKeys.Close):
+		if v.query != "" && key.Matches(msg, markdownSearchKeys.Cancel) {
+			v.search("")
+			return m, nil
+		}
+		m.modal = modalNone
+		return m, nil
+
+	case key.Matches(msg, markdownKeys.Search):
+		v.searching = true
+		v.searchInput.SetValue(v.query)
+		v.searchInput.Focus()
+		return m, textinput.Blink
+
+	case key.Matches(msg, markdownKeys.NextMatch, markdownKeys.PrevMatch):
+		if len(v.matches) == 0 {
+			return m, nil
+		}
+		step := 1
+		if key.Matches(msg, markdownKeys.PrevMatch) {
+			step = len(v.matches) - 1
+		}
+		v.matchIdx = (v.matchIdx + step) % len(v.matches)
+		v.refresh()
+		v.jump(v.matches[v.matchIdx])
+		return m, nil
+
+	case key.Matches(msg, markdownKeys.NextHeading):
+		if line, ok := v.nextHeading(1); ok {
+			v.jump(line)
+		}
+		return m, nil
+
+	case key.Matches(msg, markdownKeys.PrevHeading):
+		if line, ok := v.nextHeading(-1); ok {
+			v.jump(line)
+		}
+		return m, nil
+
+	case key.Matches(msg, markdownKeys.Top):
+		v.viewport.GotoTop()
+		return m, nil
+
+	case key.Matches(msg, markdownKeys.Bottom):
+		v.viewport.GotoBottom()
+		return m, nil
+
+	case key.Matches(msg, markdownKeys.Open):
+		if !mux.HasOpener() {
+			m.statusMessage = mutedStyle.Render("Set browser_opener to open files externally")
+			return m, nil
+		}
+		m.modal = modalNone
+		return m, runExternalCmd(mux.OpenerCmd(v.path))
+	}
+
+	var cmd tea.Cmd
+	v.viewport, cmd = v.viewport.Update(msg)
+	return m, cmd
+}
+
+func (m Model) renderMarkdownView() string {
+	v := m.mdViewer
+
+	title := projectNameStyle.Render(filepath.Base(v.path))
+	position := fmt.Sprintf("%3.0f%%", v.viewport.ScrollPercent()*100)
+	header := title + "  " + mutedStyle.Render(truncateMiddle(v.path, max(m.width-lipgloss.Width(title)-12, 10))) + "  " + mutedStyle.Render(position)
+
+	var status string
+	switch {
+	case v.searching:
+		status = v.searchInput.View()
+	case v.query != "" && len(v.matches) == 0:
+		status = errorStyle.Render(fmt.Sprintf("no matches for %q", v.query))
+	case v.query != "":
+		status = statusMsgStyle.Render(fmt.Sprintf("%q %d/%d", v.query, v.matchIdx+1, len(v.matches))) +
+			helpTextStyle.Render("  n/N next/prev • esc clear")
+	case m.statusMessage != "":
+		status = m.statusMessage
+	default:
+		hint := "j/k scroll • ]/[ headings • / search • q close"
+		if mux.HasOpener() {
+			hint += " • o open externally"
+		}
+		status = helpTextStyle.Render(hint)
+	}
+
+	return topBarStyle.Width(m.width).Render(header) + "\n" +
+		v.viewport.View() + "\n" +
+		status
+}
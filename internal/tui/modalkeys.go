@@ -0,0 +1,199 @@
+package tui
+
+import (
+	"reflect"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+)
+
+// Keys handled by the pickers and modals. They aren't remappable, but each
+// handler matches on these bindings and the help overlay lists the same
+// values (every field, in declaration order), so the two can't drift apart.
+
+func newBinding(keys []string, label, desc string) key.Binding {
+	return key.NewBinding(key.WithKeys(keys...), key.WithHelp(label, desc))
+}
+
+// withHelp returns a copy of b with its own help text
+func withHelp(b key.Binding, label, desc string) key.Binding {
+	b.SetHelp(label, desc)
+	return b
+}
+
+// Bindings shared by every list; text inputs use the ctrl variants so
+// letters can still be typed
+var (
+	listUp    = newBinding([]string{"up", "k"}, "↑/k", "move up")
+	listDown  = newBinding([]string{"down", "j"}, "↓/j", "move down")
+	inputUp   = newBinding([]string{"up", "ctrl+p", "ctrl+k"}, "↑/C-p", "move up")
+	inputDown = newBinding([]string{"down", "ctrl+n", "ctrl+j"}, "↓/C-n", "move down")
+)
+
+// workspaceNumberKeys switches workspace by position in the main view
+var workspaceNumberKeys = newBinding(reservedKeys, "1-9", "switch workspace")
+
+var gridKeys = struct {
+	Left, Right, Up, Down, Back key.Binding
+}{
+	Left:  newBinding([]string{"left"}, "←", "select workspace to the left"),
+	Right: newBinding([]string{"right"}, "→", "select workspace to the right"),
+	Up:    newBinding([]string{"up"}, "↑", "select workspace above"),
+	Down:  newBinding([]string{"down"}, "↓", "select workspace below"),
+	Back:  newBinding([]string{"enter", "esc"}, "enter/esc", "back to the workspace view"),
+}
+
+var tabPickerKeys = struct {
+	Up, Down, Position, Open, Close key.Binding
+}{
+	Up:       listUp,
+	Down:     listDown,
+	Position: newBinding([]string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}, "1-9", "open tab by position"),
+	Open:     newBinding([]string{"enter"}, "enter", "open tab or create a new one"),
+	Close:    newBinding([]string{"esc"}, "esc", "close"),
+}
+
+var tabTypePickerKeys = struct {
+	Up, Down, Position, Create, Back key.Binding
+}{
+	Up:       listUp,
+	Down:     listDown,
+	Position: newBinding([]string{"1", "2", "3", "4"}, "1-4", "create tab by type position"),
+	Create:   newBinding([]string{"enter"}, "enter", "create tab"),
+	Back:     newBinding([]string{"esc"}, "esc", "back to the tab picker"),
+}
+
+var notesPickerKeys = struct {
+	Up, Down, Edit, Read, History, Close key.Binding
+}{
+	Up:      listUp,
+	Down:    listDown,
+	Edit:    newBinding([]string{"enter"}, "enter", "edit notes"),
+	Read:    newBinding([]string{"r"}, "r", "read notes in the viewer"),
+	History: newBinding([]string{"h"}, "h", "browse notes history"),
+	Close:   newBinding([]string{"esc"}, "esc", "close"),
+}
+
+var todosKeys = struct {
+	Up, Down, Toggle, Read, Search, Close key.Binding
+}{
+	Up:     listUp,
+	Down:   listDown,
+	Toggle: newBinding([]string{" ", "x"}, "space/x", "check or uncheck item in its notes file"),
+	Read:   newBinding([]string{"enter"}, "enter", "read notes in the viewer"),
+	Search: newBinding([]string{"/"}, "/", "search all notes"),
+	Close:  newBinding([]string{"esc", "q"}, "esc/q", "close"),
+}
+
+var notesSearchKeys = struct {
+	Up, Down, Read, Back key.Binding
+}{
+	Up:   newBinding([]string{"up", "ctrl+p"}, "↑/C-p", "move up"),
+	Down: newBinding([]string{"down", "ctrl+n"}, "↓/C-n", "move down"),
+	Read: newBinding([]string{"enter"}, "enter", "read notes in the viewer"),
+	Back: newBinding([]string{"esc"}, "esc", "back to todos"),
+}
+
+var historyKeys = struct {
+	Up, Down, ScrollUp, ScrollDown, Restore, Close key.Binding
+}{
+	Up:         withHelp(listUp, "↑/k", "newer version"),
+	Down:       withHelp(listDown, "↓/j", "older version"),
+	ScrollUp:   newBinding([]string{"K", "pgup"}, "K/pgup", "scroll diff up"),
+	ScrollDown: newBinding([]string{"J", "pgdown"}, "J/pgdown", "scroll diff down"),
+	Restore:    newBinding([]string{"r"}, "r", "restore version (current notes are kept in history)"),
+	Close:      newBinding([]string{"esc", "q"}, "esc/q", "close"),
+}
+
+var sendNotesKeys = struct {
+	Up, Down, Choose, Back key.Binding
+}{
+	Up:     listUp,
+	Down:   listDown,
+	Choose: newBinding([]string{"enter"}, "enter", "pick notes or a section, then the agent tab"),
+	Back:   newBinding([]string{"esc"}, "esc", "back/close"),
+}
+
+var paletteKeys = struct {
+	Up, Down, Run, Close key.Binding
+}{
+	Up:    inputUp,
+	Down:  inputDown,
+	Run:   newBinding([]string{"enter"}, "enter", "run command"),
+	Close: newBinding([]string{"esc", "ctrl+c"}, "esc", "close"),
+}
+
+var mdLuncherKeys = struct {
+	Up, Down, Open, Close key.Binding
+}{
+	Up:    inputUp,
+	Down:  inputDown,
+	Open:  newBinding([]string{"enter"}, "enter", "open in the viewer"),
+	Close: newBinding([]string{"esc"}, "esc", "close"),
+}
+
+var newWorkspaceKeys = struct {
+	Switch, Up, Down, Create, Cancel key.Binding
+}{
+	Switch: newBinding([]string{"tab", "shift+tab"}, "tab", "switch between branch and base"),
+	Up:     withHelp(listUp, "↑/k", "previous base branch"),
+	Down:   withHelp(listDown, "↓/j", "next base branch"),
+	Create: newBinding([]string{"enter"}, "enter", "create workspace"),
+	Cancel: newBinding([]string{"esc"}, "esc", "cancel"),
+}
+
+var confirmKeys = struct {
+	Yes, Snapshot, Cancel key.Binding
+}{
+	Yes:      newBinding([]string{"y"}, "y", "confirm kill, remove or write wt.json"),
+	Snapshot: newBinding([]string{"s"}, "s", "snapshot scrollback, then kill session"),
+	Cancel:   newBinding([]string{"esc", "n"}, "esc/n", "cancel"),
+}
+
+// markdownKeys scroll with the viewport's own keymap, which the viewer uses
+var markdownKeys = struct {
+	Up, Down, NextHeading, PrevHeading, Search, NextMatch, PrevMatch, Top, Bottom, Open, Close key.Binding
+}{
+	Up:          withHelp(viewport.DefaultKeyMap().Up, "↑/k", "scroll up"),
+	Down:        withHelp(viewport.DefaultKeyMap().Down, "↓/j", "scroll down"),
+	NextHeading: newBinding([]string{"]", "tab"}, "]", "next heading"),
+	PrevHeading: newBinding([]string{"[", "shift+tab"}, "[", "previous heading"),
+	Search:      newBinding([]string{"/"}, "/", "search"),
+	NextMatch:   newBinding([]string{"n"}, "n", "next match"),
+	PrevMatch:   newBinding([]string{"N"}, "N", "previous match"),
+	Top:         newBinding([]string{"g", "home"}, "g", "top"),
+	Bottom:      newBinding([]string{"G", "end"}, "G", "bottom"),
+	Open:        newBinding([]string{"o"}, "o", "open with browser_opener"),
+	Close:       newBinding([]string{"q", "esc"}, "q/esc", "close (esc clears a search first)"),
+}
+
+var markdownSearchKeys = struct {
+	Apply, Cancel key.Binding
+}{
+	Apply:  newBinding([]string{"enter"}, "enter", "search and jump to the first match"),
+	Cancel: newBinding([]string{"esc"}, "esc", "cancel search"),
+}
+
+var helpOverlayKeys = struct {
+	Up, Down, Close key.Binding
+}{
+	Up:    withHelp(listUp, "↑/k", "scroll up"),
+	Down:  withHelp(listDown, "↓/j", "scroll down"),
+	Close: newBinding([]string{"?", "esc", "q"}, "?/esc/q", "close"),
+}
+
+// bindingHelp returns the help entries of every enabled key.Binding field of
+// a keys struct, in declaration order
+func bindingHelp(keys any) [][2]string {
+	v := reflect.ValueOf(keys)
+	var entries [][2]string
+	for i := 0; i < v.NumField(); i++ {
+		binding, ok := v.Field(i).Interface().(key.Binding)
+		if !ok || !binding.Enabled() {
+			continue
+		}
+		help := binding.Help()
+		entries = append(entries, [2]string{help.Key, help.Desc})
+	}
+	return entries
+}
@@ -0,0 +1,201 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// handleMouse maps clicks and wheel events onto the same actions as keys
+func (m Model) handleMouse(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
+	if msg.Action != tea.MouseActionPress {
+		return m, nil
+	}
+
+	if m.modal != modalNone {
+		switch msg.Button {
+		case tea.MouseButtonWheelUp:
+			return m.handleModalInput(tea.KeyMsg{Type: tea.KeyUp})
+		case tea.MouseButtonWheelDown:
+			return m.handleModalInput(tea.KeyMsg{Type: tea.KeyDown})
+		case tea.MouseButtonLeft:
+			return m.clickModal(msg.X, msg.Y)
+		}
+		return m, nil
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		return m.scrollPane(msg.X, msg.Y, -1), nil
+	case tea.MouseButtonWheelDown:
+		return m.scrollPane(msg.X, msg.Y, 1), nil
+	case tea.MouseButtonLeft:
+		switch {
+		case msg.Y == 0:
+			return m.clickTopBar(msg.X)
+		case msg.Y == m.height-1:
+			return m.clickFooter(msg.X)
+		case m.gridMode:
+			return m.clickGrid(msg.X, msg.Y)
+		}
+	}
+	return m, nil
+}
+
+// hitIndex returns which of the parts, laid out from x=start with sep
+// columns between them, contains column x
+func hitIndex(parts []string, start, sep, x int) int {
+	for i, part := range parts {
+		width := lipgloss.Width(part)
+		if x >= start && x < start+width {
+			return i
+		}
+		start += width + sep
+	}
+	return -1
+}
+
+func (m Model) clickTopBar(x int) (tea.Model, tea.Cmd) {
+	// Top bar padding, project name and two spaces precede the tabs
+	start := 1 + lipgloss.Width(projectNameStyle.Render(m.projectName)) + 2
+	if idx := hitIndex(m.topBarTabs(), start, 1, x); idx >= 0 {
+		m.gridMode = false
+		return m.activate(idx)
+	}
+	return m, nil
+}
+
+func (m Model) clickFooter(x int) (tea.Model, tea.Cmd) {
+	parts, ids := m.footerParts()
+	if idx := hitIndex(parts, 1, 1, x); idx >= 0 {
+		return m.runAction(ids[idx])
+	}
+	return m, nil
+}
+
+func (m Model) clickGrid(x, y int) (tea.Model, tea.Cmd) {
+	// Main content starts below the top bar, inside mainContentStyle padding
+	col := (x - 2) / (cardWidth + 2)
+	row := (y - 2) / (cardHeight + 2)
+	if x < 2 || y < 2 || col >= m.gridColumns() {
+		return m, nil
+	}
+
+	idx := (m.gridFirstRow(m.height-4)+row)*m.gridColumns() + col
+	if idx >= len(m.workspaces) {
+		return m, nil
+	}
+	if idx == m.activeIdx {
+		// Clicking the selected card drills in, like Enter
+		m.gridMode = false
+		return m, nil
+	}
+	return m.activate(idx)
+}
+
+// scrollPane scrolls the notes or commits list under the pointer. In the
+// two-column layout notes are on the left; when stacked, notes come first.
+func (m Model) scrollPane(x, y, delta int) Model {
+	if m.gridMode || len(m.workspaces) == 0 {
+		return m
+	}
+	ws := m.workspaces[m.activeIdx]
+
+	overNotes := x < m.width/2
+	if innerWidth := m.width - 4; innerWidth < 70 {
+		panelHeight := lipgloss.Height(m.renderWorkspacePanel(ws, max(innerWidth, 20)))
+		overNotes = y-2 < panelHeight
+	}
+
+	if overNotes {
+		m.notesScroll, _ = scrollWindow(len(ws.NotesPreview), m.notesScroll+delta, visibleNotes)
+	} else {
+		m.commitScroll, _ = scrollWindow(len(ws.RecentCommits), m.commitScroll+delta, visibleCommits)
+	}
+	return m
+}
+
+// clickModal selects the clicked row of a list modal. List modals mark their
+// selected row with "> " and render rows contiguously, so the clicked index
+// is found relative to that row. The last marked line is used because text
+// inputs above a list share the "> " prompt.
+func (m Model) clickModal(x, y int) (tea.Model, tea.Cmd) {
+	selected, count := m.modalList()
+	if count == 0 {
+		return m, nil
+	}
+
+	modal := m.renderModal()
+	top, left := m.modalPosition(m.height, modal)
+	if x < left || x >= left+lipgloss.Width(modal) {
+		return m, nil
+	}
+
+	anchor := -1
+	for i, line := range strings.Split(modal, "\n") {
+		inner := strings.TrimLeft(ansi.Strip(line), "│ ")
+		if strings.HasPrefix(inner, "> ") {
+			anchor = i
+		}
+	}
+	if anchor < 0 {
+		return m, nil
+	}
+
+	idx := selected + (y - top - anchor)
+	if idx < 0 || idx >= count {
+		return m, nil
+	}
+
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	switch m.modal {
+	case modalTabPicker:
+		m.tabPickerIdx = idx
+		return m.handleTabPickerInput(enter)
+	case modalTabTypePicker:
+		m.tabTypePickerIdx = idx
+		return m.handleTabTypePickerInput(enter)
+	case modalMdLuncher:
+		m.mdLuncherIdx = idx
+		return m.handleMdLuncherInput(enter)
+	case modalPalette:
+		m.paletteIdx = idx
+		return m.handlePaletteInput(enter)
+	case modalNotesPicker:
+		m.notesPickerIdx = idx
+		return m.handleNotesPickerInput(enter)
+	case modalSendNotes:
+		m.sendIdx = idx
+		return m.handleSendNotesInput(enter)
+	case modalNewWorkspace:
+		// Picking a base branch shouldn't create the workspace yet
+		m.baseBranchIdx = idx
+	}
+	return m, nil
+}
+
+// modalList returns the selected index and item count of the open list modal
+func (m Model) modalList() (int, int) {
+	switch m.modal {
+	case modalTabPicker:
+		return m.tabPickerIdx, len(m.tabPickerTabs) + 1 // +1 for "New"
+	case modalTabTypePicker:
+		return m.tabTypePickerIdx, len(tabTypeOptions())
+	case modalMdLuncher:
+		return m.mdLuncherIdx, len(m.mdLuncherMatches)
+	case modalPalette:
+		return m.paletteIdx, len(m.paletteMatches)
+	case modalNotesPicker:
+		return m.notesPickerIdx, len(m.notesPickerNotes)
+	case modalSendNotes:
+		if m.sendPickAgent {
+			return m.sendIdx, len(m.sendAgents)
+		}
+		return m.sendIdx, len(m.sendOptions)
+	case modalNewWorkspace:
+		return m.baseBranchIdx, len(m.baseBranchFiltered)
+	}
+	return 0, 0
+}
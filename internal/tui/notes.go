@@ -0,0 +1,178 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/emilianotisato/vibeit/internal/mux"
+	"github.com/emilianotisato/vibeit/internal/notes"
+	"github.com/emilianotisato/vibeit/internal/workspace"
+)
+
+func (m Model) notesStore() notes.Store {
+	return notes.Open(m.projectPath, m.projectName)
+}
+
+// notesPathFor returns the notes file of a workspace's branch. The git status
+// refresh resolves it (following branch renames); before that it is derived.
+func (m Model) notesPathFor(ws workspace.Workspace) string {
+	if ws.NotesPath != "" {
+		return ws.NotesPath
+	}
+	return m.notesStore().BranchPath(ws.Branch)
+}
+
+// branchNotes renders the notes template for a workspace's branch
+func (m Model) branchNotes(ws workspace.Workspace) (string, error) {
+	text, err := notes.LoadTemplate(m.settings.Notes.Template, m.projectPath)
+	if err != nil {
+		return "", err
+	}
+	data := notes.NewTemplateData(m.projectName, ws.Name, ws.Branch, workspace.BaseBranch(ws.Path), ws.Path, m.projectPath)
+	return notes.Render(text, data)
+}
+
+type notesLoggedMsg struct {
+	err error
+}
+
+// logSession appends an entry to a workspace's notes session log when
+// notes.session_log is on, creating the notes from the template first.
+func (m Model) logSession(ws workspace.Workspace, entry string) tea.Cmd {
+	if !m.settings.Notes.SessionLog {
+		return nil
+	}
+	path := m.notesPathFor(ws)
+	return func() tea.Msg {
+		content, err := m.branchNotes(ws)
+		if err == nil {
+			err = notes.Ensure(path, content)
+		}
+		if err == nil {
+			err = notes.AppendLog(path, entry)
+		}
+		return notesLoggedMsg{err: err}
+	}
+}
+
+// editNotes creates a notes file with content when missing and opens it in the editor
+func (m Model) editNotes(path, content, workDir string) (tea.Model, tea.Cmd) {
+	if err := notes.Ensure(path, content); err != nil {
+		m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to create notes: %v", err))
+		return m, nil
+	}
+	cmd := mux.EditorCmd(path)
+	cmd.Dir = workDir
+	return m, runExternalCmd(cmd)
+}
+
+func (m Model) openNotesPicker() (tea.Model, tea.Cmd) {
+	list, err := m.notesStore().List()
+	if err != nil {
+		m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to list notes: %v", err))
+		return m, nil
+	}
+
+	m.notesPickerNotes = list
+	m.notesPickerIdx = 0
+	if len(m.workspaces) > 0 {
+		current := m.notesPathFor(m.workspaces[m.activeIdx])
+		for i, note := range list {
+			if note.Path == current {
+				m.notesPickerIdx = i
+			}
+		}
+	}
+	m.modal = modalNotesPicker
+	return m, nil
+}
+
+func (m Model) handleNotesPickerInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, notesPickerKeys.Close):
+		m.modal = modalNone
+		return m, nil
+
+	case key.Matches(msg, notesPickerKeys.Up):
+		if m.notesPickerIdx > 0 {
+			m.notesPickerIdx--
+		}
+		return m, nil
+
+	case key.Matches(msg, notesPickerKeys.Down):
+		if m.notesPickerIdx < len(m.notesPickerNotes)-1 {
+			m.notesPickerIdx++
+		}
+		return m, nil
+
+	case key.Matches(msg, notesPickerKeys.Edit):
+		if len(m.notesPickerNotes) == 0 {
+			return m, nil
+		}
+		m.modal = modalNone
+		note := m.notesPickerNotes[m.notesPickerIdx]
+		workDir := m.projectPath
+		if len(m.workspaces) > 0 {
+			workDir = m.workspaces[m.activeIdx].Path
+		}
+		return m.editNotes(note.Path, fmt.Sprintf("# %s\n\n", note.Name), workDir)
+
+	case key.Matches(msg, notesPickerKeys.Read):
+		if len(m.notesPickerNotes) == 0 {
+			return m, nil
+		}
+		note := m.notesPickerNotes[m.notesPickerIdx]
+		if exists, _ := notes.Preview(note.Path, 1); !exists {
+			m.modal = modalNone
+			m.statusMessage = mutedStyle.Render("No notes yet for " + note.Name)
+			return m, nil
+		}
+		return m.openMarkdown(note.Path)
+
+	case key.Matches(msg, notesPickerKeys.History):
+		if len(m.notesPickerNotes) == 0 {
+			return m, nil
+		}
+		note := m.notesPickerNotes[m.notesPickerIdx]
+		return m.openHistory(note.Path, note.Name)
+	}
+
+	return m, nil
+}
+
+func (m Model) renderNotesPickerModal() string {
+	var content strings.Builder
+
+	content.WriteString(modalTitleStyle.Render("Notes"))
+	content.WriteString("\n\n")
+
+	// Mark notes belonging to a workspace's current branch
+	owners := map[string]string{}
+	for _, ws := range m.workspaces {
+		owners[m.notesPathFor(ws)] = ws.Name
+	}
+
+	for i, note := range m.notesPickerNotes {
+		label := note.Name
+		switch {
+		case note.Shared:
+			label += " (shared)"
+		case owners[note.Path] != "":
+			label += " · " + owners[note.Path]
+		}
+
+		prefix := "  "
+		if i == m.notesPickerIdx {
+			prefix = "> "
+			content.WriteString(modalItemSelectedStyle.Render(prefix + label))
+		} else {
+			content.WriteString(modalItemStyle.Render(prefix + label))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString(modalHintStyle.Render("Enter to edit • r to read • h history • Esc to cancel"))
+	return modalStyle.Render(content.String())
+}
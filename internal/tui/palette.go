@@ -0,0 +1,222 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/emilianotisato/vibeit/internal/fuzzy"
+	"github.com/emilianotisato/vibeit/internal/mux"
+)
+
+const paletteVisible = 12
+
+// paletteEntry is a command palette item
+type paletteEntry struct {
+	label string
+	hint  string
+	run   func(m Model) (tea.Model, tea.Cmd)
+}
+
+// openPalette collects actions, workspaces and existing tmux tabs across
+// every workspace, then shows the palette.
+func (m Model) openPalette() (tea.Model, tea.Cmd) {
+	var entries []paletteEntry
+
+	for _, action := range keyActions {
+		if action.id == "palette" {
+			continue
+		}
+		id := action.id
+		hint := ""
+		if binding := action.field(&keys); binding.Enabled() {
+			hint = binding.Help().Key
+		}
+		entries = append(entries, paletteEntry{
+			label: strings.ToUpper(action.help[:1]) + action.help[1:],
+			hint:  hint,
+			run:   func(m Model) (tea.Model, tea.Cmd) { return m.runAction(id) },
+		})
+	}
+
+	for i, ws := range m.workspaces {
+		idx := i
+		entries = append(entries, paletteEntry{
+			label: fmt.Sprintf("Switch to %s", ws.Name),
+			hint:  ws.Branch,
+			run:   func(m Model) (tea.Model, tea.Cmd) { return m.activate(idx) },
+		})
+	}
+
+	if mux.IsTmuxInstalled() {
+		for i, ws := range m.workspaces {
+			sessionName := mux.SessionName(m.projectName, ws.Name, ws.Branch)
+			if !mux.SessionExists(sessionName) {
+				continue
+			}
+			tabs, err := mux.QueryTabNames(sessionName)
+			if err != nil {
+				continue
+			}
+			for _, tab := range filterManagedTabs(tabs) {
+				idx, tabName := i, tab
+				entries = append(entries, paletteEntry{
+					label: fmt.Sprintf("%s › %s", ws.Name, tab),
+					hint:  "tab",
+					run:   func(m Model) (tea.Model, tea.Cmd) { return m.goToTab(idx, tabName) },
+				})
+			}
+		}
+	}
+
+	m.paletteEntries = entries
+	m.paletteInput.SetValue("")
+	m.paletteInput.Focus()
+	m.updatePaletteMatches()
+	m.modal = modalPalette
+	return m, textinput.Blink
+}
+
+// goToTab activates workspace idx and attaches to one of its tabs
+func (m Model) goToTab(idx int, tabName string) (tea.Model, tea.Cmd) {
+	model, activateCmd := m.activate(idx)
+	m = model.(Model)
+
+	ws := m.workspaces[idx]
+	sessionName := mux.SessionName(m.projectName, ws.Name, ws.Branch)
+	cmd := mux.GoToTabCmd(sessionName, ws.Path, tabName, m.sessionEnv(ws))
+	m.showTabPickerOnReturn = true
+	return m, tea.Batch(activateCmd, m.sessionCmd(cmd, ws, tabName, ""))
+}
+
+func (m *Model) updatePaletteMatches() {
+	labels := make([]string, len(m.paletteEntries))
+	for i, entry := range m.paletteEntries {
+		labels[i] = entry.label
+	}
+	m.paletteMatches = fuzzy.Filter(m.paletteInput.Value(), labels)
+	m.paletteIdx = 0
+}
+
+func (m Model) handlePaletteInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, paletteKeys.Close):
+		m.modal = modalNone
+		m.paletteInput.Blur()
+		return m, nil
+
+	case key.Matches(msg, paletteKeys.Up):
+		if m.paletteIdx > 0 {
+			m.paletteIdx--
+		}
+		return m, nil
+
+	case key.Matches(msg, paletteKeys.Down):
+		if m.paletteIdx < len(m.paletteMatches)-1 {
+			m.paletteIdx++
+		}
+		return m, nil
+
+	case key.Matches(msg, paletteKeys.Run):
+		if len(m.paletteMatches) == 0 {
+			return m, nil
+		}
+		m.modal = modalNone
+		m.paletteInput.Blur()
+		entry := m.paletteEntries[m.paletteMatches[m.paletteIdx].Index]
+		return entry.run(m)
+	}
+
+	var cmd tea.Cmd
+	m.paletteInput, cmd = m.paletteInput.Update(msg)
+	m.updatePaletteMatches()
+	return m, cmd
+}
+
+func (m Model) renderPaletteModal() string {
+	width := 44
+
+	var content strings.Builder
+	content.WriteString(modalTitleStyle.Render("Commands"))
+	content.WriteString("\n\n")
+	content.WriteString(m.paletteInput.View())
+	content.WriteString("\n\n")
+
+	if len(m.paletteMatches) == 0 {
+		content.WriteString(mutedStyle.Render("  no matches"))
+		content.WriteString("\n")
+	}
+
+	// Keep the selection inside the visible window
+	start := 0
+	if m.paletteIdx >= paletteVisible {
+		start = m.paletteIdx - paletteVisible + 1
+	}
+	end := min(start+paletteVisible, len(m.paletteMatches))
+
+	for i := start; i < end; i++ {
+		match := m.paletteMatches[i]
+		entry := m.paletteEntries[match.Index]
+
+		base, highlight := modalItemStyle, matchStyle
+		prefix := "  "
+		if i == m.paletteIdx {
+			base, highlight = modalItemSelectedStyle, modalItemSelectedStyle.Underline(true)
+			prefix = "> "
+		}
+
+		hint := truncateText(entry.hint, 14)
+		labelWidth := width - len(prefix) - lipgloss.Width(hint) - 1
+		label := truncateText(entry.label, labelWidth)
+		padding := labelWidth - lipgloss.Width(label) + 1
+
+		content.WriteString(base.Render(prefix))
+		content.WriteString(highlightMatches(label, match.Positions, base, highlight))
+		content.WriteString(base.Render(strings.Repeat(" ", padding)))
+		content.WriteString(mutedStyle.Render(hint))
+		content.WriteString("\n")
+	}
+
+	if len(m.paletteMatches) > paletteVisible {
+		content.WriteString(mutedStyle.Render(fmt.Sprintf("  %d/%d", m.paletteIdx+1, len(m.paletteMatches))))
+		content.WriteString("\n")
+	}
+
+	content.WriteString(modalHintStyle.Render("Enter to run • ↑/↓ to move • Esc to cancel"))
+	return modalStyle.Render(content.String())
+}
+
+// highlightMatches renders text with the runes at positions in the highlight style
+func highlightMatches(text string, positions []int, base, highlight lipgloss.Style) string {
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos] = true
+	}
+
+	var b strings.Builder
+	var run []rune
+	runMatched := false
+	flush := func() {
+		if len(run) == 0 {
+			return
+		}
+		if runMatched {
+			b.WriteString(highlight.Render(string(run)))
+		} else {
+			b.WriteString(base.Render(string(run)))
+		}
+		run = run[:0]
+	}
+	for i, r := range []rune(text) {
+		if matched[i] != runMatched {
+			flush()
+			runMatched = matched[i]
+		}
+		run = append(run, r)
+	}
+	flush()
+	return b.String()
+}
@@ -0,0 +1,158 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/emilianotisato/vibeit/internal/mux"
+	"github.com/emilianotisato/vibeit/internal/notes"
+)
+
+// sendOption is a part of the notes that can be sent to an agent
+type sendOption struct {
+	label string
+	text  string
+}
+
+// openSendNotes offers the current notes, or one of their sections, to paste
+// into an agent tab of the workspace's session
+func (m Model) openSendNotes() (tea.Model, tea.Cmd) {
+	if !mux.IsTmuxInstalled() {
+		m.statusMessage = errorStyle.Render("tmux not installed. Run 'vibeit doctor' for help.")
+		return m, nil
+	}
+
+	ws := m.workspaces[m.activeIdx]
+	data, err := os.ReadFile(m.notesPathFor(ws))
+	if err != nil {
+		m.statusMessage = mutedStyle.Render("No notes yet for " + ws.Branch)
+		return m, nil
+	}
+
+	sessionName := mux.SessionName(m.projectName, ws.Name, ws.Branch)
+	var agents []string
+	if tabs, err := mux.QueryTabNames(sessionName); err == nil {
+		for _, tab := range tabs {
+			if isAgentTab(tab) {
+				agents = append(agents, tab)
+			}
+		}
+	}
+	if len(agents) == 0 {
+		m.statusMessage = mutedStyle.Render("No agent tabs open. Start one with c or x first")
+		return m, nil
+	}
+
+	text := string(data)
+	options := []sendOption{{label: fmt.Sprintf("Whole notes (%d lines)", strings.Count(strings.TrimRight(text, "\n"), "\n")+1), text: text}}
+	for _, section := range notes.Sections(text) {
+		options = append(options, sendOption{
+			label: strings.Repeat("  ", section.Level-1) + section.Title,
+			text:  section.Body,
+		})
+	}
+
+	m.modal = modalSendNotes
+	m.sendOptions = options
+	m.sendAgents = agents
+	m.sendSession = sessionName
+	m.sendPickAgent = false
+	m.sendIdx = 0
+	m.sendOptionIdx = 0
+	return m, nil
+}
+
+func (m Model) handleSendNotesInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	count := len(m.sendOptions)
+	if m.sendPickAgent {
+		count = len(m.sendAgents)
+	}
+
+	switch {
+	case key.Matches(msg, sendNotesKeys.Back):
+		if m.sendPickAgent {
+			m.sendPickAgent = false
+			m.sendIdx = m.sendOptionIdx
+			return m, nil
+		}
+		m.modal = modalNone
+		return m, nil
+
+	case key.Matches(msg, sendNotesKeys.Up):
+		if m.sendIdx > 0 {
+			m.sendIdx--
+		}
+		return m, nil
+
+	case key.Matches(msg, sendNotesKeys.Down):
+		if m.sendIdx < count-1 {
+			m.sendIdx++
+		}
+		return m, nil
+
+	case key.Matches(msg, sendNotesKeys.Choose):
+		if !m.sendPickAgent {
+			m.sendOptionIdx = m.sendIdx
+			if len(m.sendAgents) > 1 {
+				m.sendPickAgent = true
+				m.sendIdx = 0
+				return m, nil
+			}
+			return m.sendNotes(m.sendAgents[0])
+		}
+		return m.sendNotes(m.sendAgents[m.sendIdx])
+	}
+
+	return m, nil
+}
+
+// sendNotes pastes the chosen notes into an agent tab and switches to it, so
+// the prompt can be reviewed and submitted there
+func (m Model) sendNotes(tabName string) (tea.Model, tea.Cmd) {
+	m.modal = modalNone
+	option := m.sendOptions[m.sendOptionIdx]
+	if err := mux.PasteToTab(m.sendSession, tabName, option.text); err != nil {
+		m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to send notes: %v", err))
+		return m, nil
+	}
+
+	ws := m.workspaces[m.activeIdx]
+	cmd := mux.GoToTabCmd(m.sendSession, ws.Path, tabName, m.sessionEnv(ws))
+	m.showTabPickerOnReturn = true
+	return m, m.sessionCmd(cmd, ws, tabName, "")
+}
+
+func (m Model) renderSendNotesModal() string {
+	var content strings.Builder
+
+	title, items, hint := "Send notes", make([]string, 0, len(m.sendOptions)), "Enter to choose • Esc to cancel"
+	for _, option := range m.sendOptions {
+		items = append(items, truncateText(option.label, 48))
+	}
+	if m.sendPickAgent {
+		title = "Send to"
+		items = m.sendAgents
+		hint = "Enter to paste • Esc to go back"
+	}
+
+	content.WriteString(modalTitleStyle.Render(title))
+	content.WriteString("\n\n")
+
+	start, end := scrollWindow(len(items), m.sendIdx-paletteVisible/2, paletteVisible)
+	for i := start; i < end; i++ {
+		prefix := "  "
+		if i == m.sendIdx {
+			prefix = "> "
+			content.WriteString(modalItemSelectedStyle.Render(prefix + items[i]))
+		} else {
+			content.WriteString(modalItemStyle.Render(prefix + items[i]))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString(modalHintStyle.Render(hint))
+	return modalStyle.Render(content.String())
+}
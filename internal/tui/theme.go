@@ -0,0 +1,300 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/emilianotisato/vibeit/internal/config"
+	"github.com/muesli/termenv"
+)
+
+// Theme is the palette every TUI style is built from. Values are lipgloss
+// colors: ANSI numbers ("62") or hex ("#5f5fd7").
+type Theme struct {
+	Bar         string `json:"bar"`
+	BarText     string `json:"bar_text"`
+	Accent      string `json:"accent"`
+	AccentText  string `json:"accent_text"`
+	Surface     string `json:"surface"`
+	SurfaceText string `json:"surface_text"`
+	FooterText  string `json:"footer_text"`
+	Title       string `json:"title"`
+	Text        string `json:"text"`
+	Label       string `json:"label"`
+	Hint        string `json:"hint"`
+	Muted       string `json:"muted"`
+	Warning     string `json:"warning"`
+	Good        string `json:"good"`
+	Bad         string `json:"bad"`
+	Hash        string `json:"hash"`
+	Error       string `json:"error"`
+	Success     string `json:"success"`
+	Markdown    string `json:"markdown"` // glamour style for the markdown viewer
+}
+
+const themeAuto = "auto"
+
+// builtinThemes are selectable by name through the "theme" setting
+var builtinThemes = map[string]Theme{
+	"dark": {
+		Bar: "236", BarText: "252",
+		Accent: "62", AccentText: "230",
+		Surface: "238", SurfaceText: "250",
+		FooterText: "245", Title: "212",
+		Text: "252", Label: "244", Hint: "241", Muted: "240",
+		Warning: "214", Good: "22", Bad: "160", Hash: "81",
+		Error: "196", Success: "46",
+		Markdown: "dark",
+	},
+	"light": {
+		Bar: "254", BarText: "235",
+		Accent: "62", AccentText: "231",
+		Surface: "252", SurfaceText: "238",
+		FooterText: "240", Title: "162",
+		Text: "235", Label: "242", Hint: "244", Muted: "246",
+		Warning: "166", Good: "28", Bad: "160", Hash: "25",
+		Error: "160", Success: "28",
+		Markdown: "light",
+	},
+	"high-contrast": {
+		Bar: "0", BarText: "15",
+		Accent: "11", AccentText: "0",
+		Surface: "7", SurfaceText: "0",
+		FooterText: "15", Title: "14",
+		Text: "15", Label: "15", Hint: "15", Muted: "7",
+		Warning: "11", Good: "10", Bad: "9", Hash: "14",
+		Error: "9", Success: "10",
+		Markdown: "dark",
+	},
+}
+
+// loadTheme resolves the configured theme name. "auto" picks dark or light
+// from the terminal background; user themes in "themes" start from their
+// "base" theme and override individual colors.
+func loadTheme(settings config.Settings) (Theme, error) {
+	name := settings.Theme
+	if custom, ok := settings.Themes[name]; ok {
+		base, err := builtinTheme(custom["base"])
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme %q: %w", name, err)
+		}
+		theme, err := overrideTheme(base, custom)
+		if err != nil {
+			return Theme{}, fmt.Errorf("theme %q: %w", name, err)
+		}
+		return theme, nil
+	}
+	theme, err := builtinTheme(name)
+	if err != nil {
+		return Theme{}, fmt.Errorf("%w (user themes: %s)", err, strings.Join(sortedNames(settings.Themes), ", "))
+	}
+	return theme, nil
+}
+
+func builtinTheme(name string) (Theme, error) {
+	if name == "" || name == themeAuto {
+		if lipgloss.HasDarkBackground() {
+			name = "dark"
+		} else {
+			name = "light"
+		}
+	}
+	theme, ok := builtinThemes[name]
+	if !ok {
+		return Theme{}, fmt.Errorf("unknown theme %q", name)
+	}
+	return theme, nil
+}
+
+// overrideTheme replaces the colors named in overrides, rejecting unknown names
+func overrideTheme(base Theme, overrides map[string]string) (Theme, error) {
+	data, err := json.Marshal(base)
+	if err != nil {
+		return base, err
+	}
+	var colors map[string]string
+	if err := json.Unmarshal(data, &colors); err != nil {
+		return base, err
+	}
+	for name, color := range overrides {
+		if name == "base" {
+			continue
+		}
+		if _, ok := colors[name]; !ok {
+			return base, fmt.Errorf("unknown theme color %q", name)
+		}
+		colors[name] = color
+	}
+	data, err = json.Marshal(colors)
+	if err != nil {
+		return base, err
+	}
+	var theme Theme
+	err = json.Unmarshal(data, &theme)
+	return theme, err
+}
+
+func sortedNames(themes map[string]map[string]string) []string {
+	names := make([]string, 0, len(themes))
+	for name := range themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return []string{"none"}
+	}
+	return names
+}
+
+// noColor reports whether NO_COLOR (https://no-color.org) is set
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// applyTheme (re)builds every style from the palette. Without color, selection
+// falls back to reverse video so the active tab and picker rows stay visible.
+func applyTheme(t Theme) {
+	reverse := noColor()
+	if reverse {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+	c := func(color string) lipgloss.Color { return lipgloss.Color(color) }
+
+	markdownStyle = t.Markdown
+	if reverse {
+		markdownStyle = "notty"
+	}
+
+	topBarStyle = lipgloss.NewStyle().
+		Background(c(t.Bar)).
+		Foreground(c(t.BarText)).
+		Padding(0, 1)
+
+	activeTabStyle = lipgloss.NewStyle().
+		Background(c(t.Accent)).
+		Foreground(c(t.AccentText)).
+		Bold(true).
+		Reverse(reverse).
+		Padding(0, 2)
+
+	inactiveTabStyle = lipgloss.NewStyle().
+		Background(c(t.Surface)).
+		Foreground(c(t.SurfaceText)).
+		Padding(0, 2)
+
+	dirtyIndicator = lipgloss.NewStyle().
+		Foreground(c(t.Warning)).
+		SetString("*")
+
+	footerStyle = lipgloss.NewStyle().
+		Background(c(t.Bar)).
+		Foreground(c(t.FooterText)).
+		Padding(0, 1)
+
+	footerKeyStyle = lipgloss.NewStyle().
+		Background(c(t.Accent)).
+		Foreground(c(t.AccentText)).
+		Reverse(reverse).
+		Padding(0, 1)
+
+	footerDescStyle = lipgloss.NewStyle().
+		Foreground(c(t.SurfaceText)).
+		Padding(0, 1, 0, 0)
+
+	mainContentStyle = lipgloss.NewStyle().
+		Padding(1, 2)
+
+	projectNameStyle = lipgloss.NewStyle().
+		Foreground(c(t.Title)).
+		Bold(true)
+
+	helpTextStyle = lipgloss.NewStyle().
+		Foreground(c(t.Hint))
+
+	statusMsgStyle = lipgloss.NewStyle().
+		Foreground(c(t.Warning)).
+		Bold(true)
+
+	sectionTitleStyle = lipgloss.NewStyle().
+		Foreground(c(t.Label)).
+		Bold(true)
+
+	labelStyle = lipgloss.NewStyle().
+		Foreground(c(t.Label))
+
+	valueStyle = lipgloss.NewStyle().
+		Foreground(c(t.Text))
+
+	mutedStyle = lipgloss.NewStyle().
+		Foreground(c(t.Muted))
+
+	pillStyle = lipgloss.NewStyle().
+		Foreground(c(t.AccentText)).
+		Background(c(t.Surface)).
+		Bold(true).
+		Padding(0, 1)
+
+	pillGoodStyle = pillStyle.
+		Background(c(t.Good))
+
+	pillWarnStyle = pillStyle.
+		Background(c(t.Bad))
+
+	pillInfoStyle = pillStyle.
+		Background(c(t.Accent))
+
+	commitHashStyle = lipgloss.NewStyle().
+		Foreground(c(t.Hash)).
+		Bold(true)
+
+	modalStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(c(t.Accent)).
+		Padding(1, 2).
+		Width(50)
+
+	modalTitleStyle = lipgloss.NewStyle().
+		Foreground(c(t.Title)).
+		Bold(true).
+		MarginBottom(1)
+
+	modalHintStyle = lipgloss.NewStyle().
+		Foreground(c(t.Hint)).
+		MarginTop(1)
+
+	modalItemStyle = lipgloss.NewStyle().
+		Foreground(c(t.Text))
+
+	modalItemSelectedStyle = lipgloss.NewStyle().
+		Foreground(c(t.AccentText)).
+		Background(c(t.Accent)).
+		Reverse(reverse).
+		Bold(true)
+
+	cardStyle = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(c(t.Muted)).
+		Width(cardWidth).
+		Height(cardHeight)
+
+	cardSelectedStyle = cardStyle.
+		BorderForeground(c(t.Accent))
+	if reverse {
+		cardSelectedStyle = cardSelectedStyle.Border(lipgloss.ThickBorder())
+	}
+
+	matchStyle = lipgloss.NewStyle().
+		Foreground(c(t.Warning)).
+		Bold(true).
+		Underline(reverse)
+
+	errorStyle = lipgloss.NewStyle().
+		Foreground(c(t.Error))
+
+	successStyle = lipgloss.NewStyle().
+		Foreground(c(t.Success))
+}
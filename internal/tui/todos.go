@@ -0,0 +1,289 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/emilianotisato/vibeit/internal/notes"
+)
+
+const todosVisible = 14
+
+// notesGroup is a notes file with the label it is listed under
+type notesGroup struct {
+	label string
+	path  string
+}
+
+// todoRow is a line of the todos modal: a group heading or a checklist item
+type todoRow struct {
+	heading string
+	todo    notes.Todo
+}
+
+// notesGroups lists every notes file: the workspaces' branch notes in tab
+// order, the shared notes, then notes of branches no workspace has checked out.
+func (m Model) notesGroups() []notesGroup {
+	list, _ := m.notesStore().List()
+
+	var groups []notesGroup
+	seen := map[string]bool{}
+	for i, ws := range m.workspaces {
+		path := m.notesPathFor(ws)
+		groups = append(groups, notesGroup{label: fmt.Sprintf("%d:%s", i+1, ws.Branch), path: path})
+		seen[path] = true
+	}
+	for _, note := range list {
+		if seen[note.Path] {
+			continue
+		}
+		label := note.Name
+		if note.Shared {
+			label = "shared"
+		}
+		groups = append(groups, notesGroup{label: label, path: note.Path})
+	}
+	return groups
+}
+
+func (m Model) openTodos() (tea.Model, tea.Cmd) {
+	m.modal = modalTodos
+	m.todoIdx = 0
+	m.todoError = ""
+	m.todoSearching = false
+	m.todoHits = nil
+	m.todoInput.SetValue("")
+	m.todoInput.Blur()
+	m.loadTodos()
+	return m, nil
+}
+
+// loadTodos reads checklist items from every notes file, keeping the selection
+func (m *Model) loadTodos() {
+	m.todoRows = nil
+	for _, group := range m.notesGroups() {
+		todos, err := notes.Todos(group.path)
+		if err != nil || len(todos) == 0 {
+			continue
+		}
+		open := 0
+		for _, todo := range todos {
+			if !todo.Done {
+				open++
+			}
+		}
+		m.todoRows = append(m.todoRows, todoRow{heading: fmt.Sprintf("%s (%d open)", group.label, open)})
+		for _, todo := range todos {
+			m.todoRows = append(m.todoRows, todoRow{todo: todo})
+		}
+	}
+	m.todoIdx = m.nextTodoRow(m.todoIdx, 1)
+}
+
+// nextTodoRow returns the first item row from idx in direction dir, skipping
+// headings, or the nearest item the other way at the ends of the list.
+func (m Model) nextTodoRow(idx, dir int) int {
+	for i := idx; i >= 0 && i < len(m.todoRows); i += dir {
+		if m.todoRows[i].heading == "" {
+			return i
+		}
+	}
+	for i := idx; i >= 0 && i < len(m.todoRows); i -= dir {
+		if m.todoRows[i].heading == "" {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m *Model) updateTodoHits() {
+	var paths []string
+	for _, group := range m.notesGroups() {
+		paths = append(paths, group.path)
+	}
+	m.todoHits = notes.Search(paths, m.todoInput.Value())
+	m.todoIdx = 0
+}
+
+func (m Model) handleTodosInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.todoSearching {
+		return m.handleNotesSearchInput(msg)
+	}
+
+	switch {
+	case key.Matches(msg, todosKeys.Close):
+		m.modal = modalNone
+		return m, nil
+
+	case key.Matches(msg, todosKeys.Up):
+		if m.todoIdx > 0 {
+			m.todoIdx = m.nextTodoRow(m.todoIdx-1, -1)
+		}
+		return m, nil
+
+	case key.Matches(msg, todosKeys.Down):
+		if m.todoIdx < len(m.todoRows)-1 {
+			m.todoIdx = m.nextTodoRow(m.todoIdx+1, 1)
+		}
+		return m, nil
+
+	case key.Matches(msg, todosKeys.Toggle):
+		if len(m.todoRows) == 0 {
+			return m, nil
+		}
+		if err := notes.Toggle(m.todoRows[m.todoIdx].todo); err != nil {
+			m.todoError = err.Error()
+		} else {
+			m.todoError = ""
+		}
+		m.loadTodos()
+		return m, refreshGitStatus(m.workspaces, m.projectPath, m.projectName)
+
+	case key.Matches(msg, todosKeys.Read):
+		if len(m.todoRows) == 0 {
+			return m, nil
+		}
+		return m.openMarkdown(m.todoRows[m.todoIdx].todo.Path)
+
+	case key.Matches(msg, todosKeys.Search):
+		m.todoSearching = true
+		m.todoIdx = 0
+		m.todoInput.SetValue("")
+		m.todoHits = nil
+		m.todoInput.Focus()
+		return m, textinput.Blink
+	}
+
+	return m, nil
+}
+
+func (m Model) handleNotesSearchInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, notesSearchKeys.Back):
+		m.todoSearching = false
+		m.todoInput.Blur()
+		m.todoIdx = m.nextTodoRow(0, 1)
+		return m, nil
+
+	case key.Matches(msg, notesSearchKeys.Up):
+		if m.todoIdx > 0 {
+			m.todoIdx--
+		}
+		return m, nil
+
+	case key.Matches(msg, notesSearchKeys.Down):
+		if m.todoIdx < len(m.todoHits)-1 {
+			m.todoIdx++
+		}
+		return m, nil
+
+	case key.Matches(msg, notesSearchKeys.Read):
+		if len(m.todoHits) == 0 {
+			return m, nil
+		}
+		return m.openMarkdown(m.todoHits[m.todoIdx].Path)
+	}
+
+	var cmd tea.Cmd
+	m.todoInput, cmd = m.todoInput.Update(msg)
+	m.updateTodoHits()
+	return m, cmd
+}
+
+func (m Model) renderTodosModal() string {
+	width := 56
+
+	var content strings.Builder
+	if m.todoSearching {
+		content.WriteString(modalTitleStyle.Render("Search notes"))
+		content.WriteString("\n\n")
+		content.WriteString(m.todoInput.View())
+		content.WriteString("\n\n")
+		content.WriteString(m.renderNotesHits(width))
+		content.WriteString(modalHintStyle.Render("Enter to read • ↑/↓ to move • Esc to go back"))
+		return modalStyle.Render(content.String())
+	}
+
+	content.WriteString(modalTitleStyle.Render("Notes todos"))
+	content.WriteString("\n\n")
+
+	if len(m.todoRows) == 0 {
+		content.WriteString(mutedStyle.Render("  no \"- [ ]\" items in any notes"))
+		content.WriteString("\n")
+	}
+
+	start, end := scrollWindow(len(m.todoRows), m.todoIdx-todosVisible/2, todosVisible)
+	for i := start; i < end; i++ {
+		row := m.todoRows[i]
+		if row.heading != "" {
+			content.WriteString(labelStyle.Render(truncateText(row.heading, width)))
+			content.WriteString("\n")
+			continue
+		}
+
+		box := "[ ] "
+		style := modalItemStyle
+		if row.todo.Done {
+			box = "[x] "
+			style = mutedStyle
+		}
+		prefix := "  "
+		if i == m.todoIdx {
+			prefix = "> "
+			style = modalItemSelectedStyle
+		}
+		content.WriteString(style.Render(prefix + box + truncateText(row.todo.Text, width-6)))
+		content.WriteString("\n")
+	}
+
+	if m.todoError != "" {
+		content.WriteString(errorStyle.Render(m.todoError))
+		content.WriteString("\n")
+	}
+
+	content.WriteString(modalHintStyle.Render("Space toggle • Enter read • / search • Esc close"))
+	return modalStyle.Render(content.String())
+}
+
+func (m Model) renderNotesHits(width int) string {
+	if strings.TrimSpace(m.todoInput.Value()) == "" {
+		return ""
+	}
+	if len(m.todoHits) == 0 {
+		return mutedStyle.Render("  no matches") + "\n"
+	}
+
+	labels := map[string]string{}
+	for _, group := range m.notesGroups() {
+		labels[group.path] = group.label
+	}
+
+	var b strings.Builder
+	start, end := scrollWindow(len(m.todoHits), m.todoIdx-todosVisible/2, todosVisible)
+	for i := start; i < end; i++ {
+		hit := m.todoHits[i]
+		label := labels[hit.Path]
+		if label == "" {
+			label = strings.TrimSuffix(filepath.Base(hit.Path), ".md")
+		}
+		where := fmt.Sprintf("%s:%d", truncateText(label, 18), hit.Line)
+
+		prefix, style := "  ", modalItemStyle
+		if i == m.todoIdx {
+			prefix, style = "> ", modalItemSelectedStyle
+		}
+		b.WriteString(style.Render(prefix + truncateText(hit.Text, width-len(prefix)-lipgloss.Width(where)-1)))
+		b.WriteString(" " + mutedStyle.Render(where))
+		b.WriteString("\n")
+	}
+	if len(m.todoHits) > todosVisible {
+		b.WriteString(mutedStyle.Render(fmt.Sprintf("  %d/%d", m.todoIdx+1, len(m.todoHits))))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
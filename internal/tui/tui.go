@@ -1,7 +1,6 @@
 package tui
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 	"os/exec"
@@ -13,7 +12,11 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/emilianotisato/vibeit/internal/config"
+	"github.com/emilianotisato/vibeit/internal/fuzzy"
+	"github.com/emilianotisato/vibeit/internal/hooks"
 	"github.com/emilianotisato/vibeit/internal/mux"
+	"github.com/emilianotisato/vibeit/internal/notes"
 	"github.com/emilianotisato/vibeit/internal/workspace"
 	workspace_init "github.com/emilianotisato/vibeit/internal/workspace_init"
 )
@@ -26,206 +29,59 @@ const (
 	modalNewWorkspace
 	modalTabPicker
 	modalTabTypePicker
-	modalMdLuncherFolder
-	modalMdLuncherSelect
+	modalMdLuncher
+	modalRemoveWorkspace
+	modalInitConfig
+	modalKillSession
+	modalPalette
+	modalHelp
+	modalMarkdown
+	modalNotesPicker
+	modalTodos
+	modalHistory
+	modalSendNotes
 )
 
-const gitPollInterval = 5 * time.Second
-
-// Styles
+// Styles are assigned by applyTheme
 var (
-	topBarStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("236")).
-			Foreground(lipgloss.Color("252")).
-			Padding(0, 1)
-
-	activeTabStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("62")).
-			Foreground(lipgloss.Color("230")).
-			Bold(true).
-			Padding(0, 2)
-
-	inactiveTabStyle = lipgloss.NewStyle().
-				Background(lipgloss.Color("238")).
-				Foreground(lipgloss.Color("250")).
-				Padding(0, 2)
-
-	dirtyIndicator = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214")).
-			SetString("*")
-
-	footerStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("236")).
-			Foreground(lipgloss.Color("245")).
-			Padding(0, 1)
-
-	footerKeyStyle = lipgloss.NewStyle().
-			Background(lipgloss.Color("62")).
-			Foreground(lipgloss.Color("230")).
-			Padding(0, 1)
-
-	footerDescStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("250")).
-			Padding(0, 1, 0, 0)
-
-	mainContentStyle = lipgloss.NewStyle().
-				Padding(1, 2)
-
-	projectNameStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("212")).
-				Bold(true)
-
-	helpTextStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241"))
-
-	statusMsgStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("214")).
-			Bold(true)
-
-	sectionTitleStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("244")).
-				Bold(true)
-
-	labelStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("244"))
-
-	valueStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
-
-	mutedStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("240"))
-
-	pillStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("230")).
-			Background(lipgloss.Color("238")).
-			Bold(true).
-			Padding(0, 1)
-
-	pillGoodStyle = pillStyle.Copy().
-			Background(lipgloss.Color("22"))
-
-	pillWarnStyle = pillStyle.Copy().
-			Background(lipgloss.Color("160"))
-
-	pillInfoStyle = pillStyle.Copy().
-			Background(lipgloss.Color("62"))
-
-	commitHashStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("81")).
-			Bold(true)
-
-	modalStyle = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color("62")).
-			Padding(1, 2).
-			Width(50)
-
-	modalTitleStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("212")).
-			Bold(true).
-			MarginBottom(1)
-
-	modalHintStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("241")).
-			MarginTop(1)
-
-	modalItemStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("252"))
-
-	modalItemSelectedStyle = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("230")).
-				Background(lipgloss.Color("62")).
-				Bold(true)
-
-	errorStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("196"))
-
-	successStyle = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("46"))
+	topBarStyle            lipgloss.Style
+	activeTabStyle         lipgloss.Style
+	inactiveTabStyle       lipgloss.Style
+	dirtyIndicator         lipgloss.Style
+	footerStyle            lipgloss.Style
+	footerKeyStyle         lipgloss.Style
+	footerDescStyle        lipgloss.Style
+	mainContentStyle       lipgloss.Style
+	projectNameStyle       lipgloss.Style
+	helpTextStyle          lipgloss.Style
+	statusMsgStyle         lipgloss.Style
+	sectionTitleStyle      lipgloss.Style
+	labelStyle             lipgloss.Style
+	valueStyle             lipgloss.Style
+	mutedStyle             lipgloss.Style
+	pillStyle              lipgloss.Style
+	pillGoodStyle          lipgloss.Style
+	pillWarnStyle          lipgloss.Style
+	pillInfoStyle          lipgloss.Style
+	commitHashStyle        lipgloss.Style
+	modalStyle             lipgloss.Style
+	modalTitleStyle        lipgloss.Style
+	modalHintStyle         lipgloss.Style
+	modalItemStyle         lipgloss.Style
+	modalItemSelectedStyle lipgloss.Style
+	matchStyle             lipgloss.Style
+	cardStyle              lipgloss.Style
+	cardSelectedStyle      lipgloss.Style
+	errorStyle             lipgloss.Style
+	successStyle           lipgloss.Style
 )
 
-type keyMap struct {
-	Quit        key.Binding
-	NextTab     key.Binding
-	PrevTab     key.Binding
-	Terminal    key.Binding
-	Git         key.Binding
-	Claude      key.Binding
-	Codex       key.Binding
-	Neovim      key.Binding
-	Notes       key.Binding
-	Config      key.Binding
-	Workspace   key.Binding
-	KillSession key.Binding
-	Enter       key.Binding
-	CommandKey  key.Binding
-	MdLuncher   key.Binding
-}
-
-var keys = keyMap{
-	Quit: key.NewBinding(
-		key.WithKeys("q", "ctrl+c"),
-		key.WithHelp("q", "quit"),
-	),
-	NextTab: key.NewBinding(
-		key.WithKeys("tab", "l"),
-		key.WithHelp("tab", "next workspace"),
-	),
-	PrevTab: key.NewBinding(
-		key.WithKeys("shift+tab", "h"),
-		key.WithHelp("S-tab", "prev workspace"),
-	),
-	Terminal: key.NewBinding(
-		key.WithKeys("t"),
-		key.WithHelp("t", "terminal"),
-	),
-	Git: key.NewBinding(
-		key.WithKeys("g"),
-		key.WithHelp("g", "lazygit"),
-	),
-	Claude: key.NewBinding(
-		key.WithKeys("c"),
-		key.WithHelp("c", "claude"),
-	),
-	Codex: key.NewBinding(
-		key.WithKeys("x"),
-		key.WithHelp("x", "codex"),
-	),
-	Neovim: key.NewBinding(
-		key.WithKeys("v"),
-		key.WithHelp("v", "nvim"),
-	),
-	Notes: key.NewBinding(
-		key.WithKeys("n"),
-		key.WithHelp("n", "notes"),
-	),
-	Config: key.NewBinding(
-		key.WithKeys("e"),
-		key.WithHelp("e", "wt.json"),
-	),
-	Workspace: key.NewBinding(
-		key.WithKeys("w"),
-		key.WithHelp("w", "new workspace"),
-	),
-	KillSession: key.NewBinding(
-		key.WithKeys("k"),
-		key.WithHelp("k", "kill session"),
-	),
-	Enter: key.NewBinding(
-		key.WithKeys("enter"),
-		key.WithHelp("enter", "tabs"),
-	),
-	CommandKey: key.NewBinding(
-		key.WithKeys("ctrl+\\"),
-		key.WithHelp("C-\\", "command mode"),
-	),
-	MdLuncher: key.NewBinding(
-		key.WithKeys("o"),
-		key.WithHelp("o", "open md"),
-	),
+func init() {
+	applyTheme(builtinThemes["dark"])
 }
 
 type Model struct {
+	settings       config.Settings
 	projectName    string
 	projectPath    string
 	workspaces     []workspace.Workspace
@@ -237,6 +93,13 @@ type Model struct {
 	statusMessage  string
 	gitPollActive  bool
 	wtConfigExists bool
+	gitSnapshot    map[string]workspace.Workspace
+	gridMode       bool
+	commitScroll   int
+	notesScroll    int
+	cardStatus     map[string]cardStatus
+	helpScroll     int
+	mdViewer       mdViewer
 
 	// Modal state
 	modal           modalType
@@ -259,13 +122,62 @@ type Model struct {
 	showTabPickerOnReturn bool
 
 	// Md-luncher modal
-	mdLuncherFolderInput textinput.Model
-	mdLuncherFiles       []string
-	mdLuncherIdx         int
-	mdLuncherError       string
+	mdLuncherInput    textinput.Model
+	mdLuncherFiles    []string
+	mdLuncherMatches  []fuzzy.Match
+	mdLuncherIdx      int
+	mdLuncherError    string
+	mdLuncherScanning bool
+
+	// Remove workspace modal
+	removeTeardown []string
+
+	// Command palette
+	paletteInput   textinput.Model
+	paletteEntries []paletteEntry
+	paletteMatches []fuzzy.Match
+	paletteIdx     int
+
+	// Notes picker
+	notesPickerNotes []notes.Note
+	notesPickerIdx   int
+
+	// Notes todos and search
+	todoRows      []todoRow
+	todoIdx       int
+	todoError     string
+	todoSearching bool
+	todoInput     textinput.Model
+	todoHits      []notes.Hit
+
+	// Notes history
+	historyPath     string
+	historyTitle    string
+	historyVersions []notes.Version
+	historyIdx      int
+	historyDiff     []notes.DiffLine
+	historyScroll   int
+	historyError    string
+
+	// Send notes to an agent
+	sendOptions   []sendOption
+	sendAgents    []string
+	sendSession   string
+	sendPickAgent bool
+	sendIdx       int
+	sendOptionIdx int
+
+	// Kill session modal
+	killSession string
+	killWindows []mux.Window
+
+	// Init wt.json modal
+	initConfig   workspace_init.Config
+	initPreview  []string
+	initFindings []string
 }
 
-func initialModel() Model {
+func initialModel(settings config.Settings) Model {
 	branchInput := textinput.New()
 	branchInput.Placeholder = "feature-name"
 	branchInput.CharLimit = 50
@@ -276,19 +188,35 @@ func initialModel() Model {
 	baseBranchInput.CharLimit = 50
 	baseBranchInput.Width = 40
 
-	mdLuncherFolderInput := textinput.New()
-	mdLuncherFolderInput.Placeholder = "docs"
-	mdLuncherFolderInput.CharLimit = 100
-	mdLuncherFolderInput.Width = 40
+	mdLuncherInput := textinput.New()
+	mdLuncherInput.Placeholder = "type to search"
+	mdLuncherInput.Prompt = "/ "
+	mdLuncherInput.CharLimit = 100
+	mdLuncherInput.Width = 40
+
+	paletteInput := textinput.New()
+	paletteInput.Placeholder = "type to search"
+	paletteInput.Prompt = ": "
+	paletteInput.CharLimit = 100
+	paletteInput.Width = 40
+
+	todoInput := textinput.New()
+	todoInput.Placeholder = "search all notes"
+	todoInput.Prompt = "/ "
+	todoInput.CharLimit = 100
+	todoInput.Width = 40
 
 	return Model{
-		projectName:          "loading...",
-		workspaces:           []workspace.Workspace{},
-		activeIdx:            0,
-		branchInput:          branchInput,
-		baseBranchInput:      baseBranchInput,
-		activeInput:          0,
-		mdLuncherFolderInput: mdLuncherFolderInput,
+		settings:        settings,
+		todoInput:       todoInput,
+		paletteInput:    paletteInput,
+		projectName:     "loading...",
+		workspaces:      []workspace.Workspace{},
+		activeIdx:       0,
+		branchInput:     branchInput,
+		baseBranchInput: baseBranchInput,
+		activeInput:     0,
+		mdLuncherInput:  mdLuncherInput,
 	}
 }
 
@@ -305,6 +233,10 @@ type externalCmdFinishedMsg struct {
 	err error
 }
 
+type configEditedMsg struct {
+	err error
+}
+
 type gitStatusTickMsg struct{}
 
 type gitStatusMsg struct {
@@ -375,8 +307,8 @@ func determineInitialWorkspaceIndex(workspaces []workspace.Workspace) int {
 	return 0
 }
 
-func scheduleGitStatusTick() tea.Cmd {
-	return tea.Tick(gitPollInterval, func(time.Time) tea.Msg {
+func scheduleGitStatusTick(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
 		return gitStatusTickMsg{}
 	})
 }
@@ -386,10 +318,17 @@ func refreshGitStatus(workspaces []workspace.Workspace, projectPath, projectName
 	copy(wsSnapshot, workspaces)
 
 	return func() tea.Msg {
+		store := notes.Open(projectPath, projectName)
+		// History is best effort; a failed snapshot must not stop the refresh
+		notes.Snapshot(store.SharedPath())
 		for i, ws := range wsSnapshot {
 			updated := workspace.UpdateGitStatus(ws)
-			exists, preview := notesPreview(projectPath, projectName, updated.Branch)
+			// A failed migration leaves the old file in place; notes start fresh
+			updated.NotesPath, _ = store.Resolve(updated.Path, updated.Branch)
+			notes.Snapshot(updated.NotesPath)
+			exists, preview := notes.Preview(updated.NotesPath, maxNotesPreview)
 			updated.NotesExists = exists
+			updated.OpenTodos = notes.OpenTodos(updated.NotesPath)
 			updated.NotesPreview = preview
 			wsSnapshot[i] = updated
 		}
@@ -407,6 +346,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.width = msg.Width
 		m.height = msg.Height
 		m.ready = true
+		if m.modal == modalMarkdown {
+			width, height := m.markdownViewSize()
+			m.mdViewer.viewport.Height = height
+			if err := m.mdViewer.render(width); err != nil {
+				m.statusMessage = errorStyle.Render(err.Error())
+			}
+		}
 
 	case workspacesLoadedMsg:
 		m.projectName = msg.projectName
@@ -417,16 +363,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if len(m.workspaces) == 0 && m.err == nil {
 			m.err = fmt.Errorf("not a git repository")
 		}
-		// Auto-select workspace based on cwd on initial load
-		if !m.gitPollActive {
-			m.activeIdx = determineInitialWorkspaceIndex(m.workspaces)
+		if m.activeIdx >= len(m.workspaces) {
+			m.activeIdx = 0
 		}
 		cmds := []tea.Cmd{
 			refreshGitStatus(m.workspaces, m.projectPath, m.projectName),
 		}
+		// Auto-select workspace based on cwd on initial load
+		if !m.gitPollActive {
+			m.activeIdx = determineInitialWorkspaceIndex(m.workspaces)
+			if len(m.workspaces) > 0 {
+				cmds = append(cmds, m.activeHook(hooks.WorkspaceActivated))
+			}
+		}
 		if !m.gitPollActive {
 			m.gitPollActive = true
-			cmds = append(cmds, scheduleGitStatusTick())
+			cmds = append(cmds, scheduleGitStatusTick(m.gitPollInterval()))
 		}
 		if m.showTabPickerOnReturn && msg.err == nil {
 			m.showTabPickerOnReturn = false
@@ -448,14 +400,82 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, loadWorkspaces
 
+	case configEditedMsg:
+		if msg.err != nil {
+			m.statusMessage = errorStyle.Render(fmt.Sprintf("Error: %v", msg.err))
+		} else if problems := config.ValidateFile(m.projectPath, "wt.json"); len(problems) > 0 {
+			text := problems[0].String()
+			if len(problems) > 1 {
+				text += fmt.Sprintf(" (+%d more, run 'vibeit config validate')", len(problems)-1)
+			}
+			if config.HasErrors(problems) {
+				m.statusMessage = errorStyle.Render(text)
+			} else {
+				m.statusMessage = statusMsgStyle.Render(text)
+			}
+		} else {
+			m.statusMessage = successStyle.Render("wt.json is valid")
+		}
+		return m, loadWorkspaces
+
 	case gitStatusTickMsg:
 		return m, refreshGitStatus(m.workspaces, m.projectPath, m.projectName)
 
 	case gitStatusMsg:
+		cmds := []tea.Cmd{scheduleGitStatusTick(m.gitPollInterval())}
 		if msg.err == nil {
+			for _, payload := range m.gitStatusEvents(msg.workspaces) {
+				cmds = append(cmds, m.fireHook(payload))
+				if payload.Change == hooks.ChangeCommit {
+					for _, ws := range msg.workspaces {
+						if ws.Path == payload.Path && len(ws.RecentCommits) > 0 {
+							// The log is for people: short hash and subject
+							cmds = append(cmds, m.logSession(ws, "commit "+ws.RecentCommits[0]))
+						}
+					}
+				}
+			}
+			m.gitSnapshot = gitSnapshot(msg.workspaces)
 			m.workspaces = msg.workspaces
 		}
-		return m, scheduleGitStatusTick()
+		if m.gridMode {
+			cmds = append(cmds, loadCardStatus(m.projectName, m.workspaces))
+		}
+		return m, tea.Batch(cmds...)
+
+	case cardStatusMsg:
+		m.cardStatus = msg
+		return m, nil
+
+	case markdownLoadedMsg:
+		if msg.err != nil {
+			m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to open markdown: %v", msg.err))
+			return m, nil
+		}
+		m.mdViewer = msg.viewer
+		m.modal = modalMarkdown
+		return m, nil
+
+	case mdFilesMsg:
+		m.mdLuncherScanning = false
+		if msg.err != nil {
+			m.mdLuncherError = msg.err.Error()
+		}
+		m.mdLuncherFiles = msg.files
+		m.updateMdLuncherMatches()
+		return m, nil
+
+	case hookFinishedMsg:
+		if msg.err != nil {
+			m.statusMessage = errorStyle.Render(msg.err.Error())
+		}
+		return m, nil
+
+	case notesLoggedMsg:
+		if msg.err != nil {
+			m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to update session log: %v", msg.err))
+		}
+		return m, nil
 
 	case workspaceCreatedMsg:
 		m.modal = modalNone
@@ -468,6 +488,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, loadWorkspaces
 
+	case tea.MouseMsg:
+		return m.handleMouse(msg)
+
 	case tea.KeyMsg:
 		if m.modal != modalNone {
 			return m.handleModalInput(msg)
@@ -475,108 +498,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.statusMessage = ""
 
-		switch {
-		case key.Matches(msg, keys.Quit):
-			return m, tea.Quit
-
-		case key.Matches(msg, keys.NextTab):
-			if len(m.workspaces) > 0 {
-				m.activeIdx = (m.activeIdx + 1) % len(m.workspaces)
-			}
-
-		case key.Matches(msg, keys.PrevTab):
-			if len(m.workspaces) > 0 {
-				m.activeIdx = (m.activeIdx - 1 + len(m.workspaces)) % len(m.workspaces)
-			}
-
-		case key.Matches(msg, keys.Enter):
-			// Show all managed tabs
-			if len(m.workspaces) > 0 {
-				return m.showTabPicker(mux.TabType(""))
-			}
-
-		case key.Matches(msg, keys.Terminal):
-			// Multi-instance terminal tabs
-			if len(m.workspaces) > 0 {
-				return m.showTabPicker(mux.TabTerminal)
-			}
-
-		case key.Matches(msg, keys.Git):
-			// Single-instance lazygit
-			if len(m.workspaces) > 0 {
-				return m.openSingleTab(mux.TabLazygit)
-			}
-
-		case key.Matches(msg, keys.Claude):
-			// Multi-instance claude tabs
-			if len(m.workspaces) > 0 {
-				return m.showTabPicker(mux.TabClaude)
-			}
-
-		case key.Matches(msg, keys.Codex):
-			// Multi-instance codex tabs
-			if len(m.workspaces) > 0 {
-				return m.showTabPicker(mux.TabCodex)
-			}
-
-		case key.Matches(msg, keys.Neovim):
-			// Multi-instance nvim tabs
-			if len(m.workspaces) > 0 {
-				return m.showTabPicker(mux.TabNeovim)
-			}
-
-		case key.Matches(msg, keys.Notes):
-			if len(m.workspaces) > 0 {
-				return m.openNotes()
-			}
-
-		case key.Matches(msg, keys.Config):
-			return m.openWorkspaceConfig()
-
-		case key.Matches(msg, keys.MdLuncher):
-			if len(m.workspaces) > 0 {
-				m.modal = modalMdLuncherFolder
-				m.mdLuncherFolderInput.SetValue("docs")
-				m.mdLuncherFolderInput.Focus()
-				m.mdLuncherError = ""
-				return m, textinput.Blink
+		if m.gridMode {
+			if model, cmd, handled := m.handleGridInput(msg); handled {
+				return model, cmd
 			}
+		}
 
-		case key.Matches(msg, keys.Workspace):
-			m.modal = modalNewWorkspace
-			m.branchInput.SetValue("")
-			branches, err := workspace.ListBranches(m.workspaces[m.activeIdx].Path)
-			if err != nil {
-				branches = nil
-			}
-			m.baseBranchOptions = branches
-			m.baseBranchFiltered = nil
-			m.baseBranchIdx = 0
-			currentBranch := m.workspaces[m.activeIdx].Branch
-			m.baseBranchInput.SetValue("")
-			m.updateBaseBranchFilter()
-			m.baseBranchIdx = indexOfBranch(m.baseBranchFiltered, currentBranch)
-			m.branchInput.Focus()
-			m.baseBranchInput.Blur()
-			m.activeInput = 0
-			m.modalError = ""
-			return m, textinput.Blink
-
-		case key.Matches(msg, keys.KillSession):
-			if len(m.workspaces) > 0 {
-				ws := m.workspaces[m.activeIdx]
-				sessionName := mux.SessionName(m.projectName, ws.Name, ws.Branch)
-				if err := mux.DeleteSession(sessionName); err != nil {
-					m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to kill session: %v", err))
-				} else {
-					m.statusMessage = successStyle.Render(fmt.Sprintf("Killed session: %s", sessionName))
-				}
+		for _, action := range keyActions {
+			if key.Matches(msg, *action.field(&keys)) {
+				return m.runAction(action.id)
 			}
+		}
 
-		case msg.String() >= "1" && msg.String() <= "9":
+		if key.Matches(msg, workspaceNumberKeys) {
 			idx := int(msg.String()[0] - '1')
 			if idx < len(m.workspaces) {
-				m.activeIdx = idx
+				return m.activate(idx)
 			}
 		}
 	}
@@ -584,6 +521,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) gitPollInterval() time.Duration {
+	if m.settings.GitPollInterval < 1 {
+		return time.Second
+	}
+	return time.Duration(m.settings.GitPollInterval) * time.Second
+}
+
 func (m Model) attachSession() (tea.Model, tea.Cmd) {
 	if !mux.IsTmuxInstalled() {
 		m.statusMessage = errorStyle.Render("tmux not installed. Run 'vibeit doctor' for help.")
@@ -593,9 +537,27 @@ func (m Model) attachSession() (tea.Model, tea.Cmd) {
 	ws := m.workspaces[m.activeIdx]
 	sessionName := mux.SessionName(m.projectName, ws.Name, ws.Branch)
 
-	cmd := mux.AttachOrCreateCmd(sessionName, ws.Path)
+	cmd := mux.AttachOrCreateCmd(sessionName, ws.Path, m.sessionEnv(ws))
 	m.showTabPickerOnReturn = true
-	return m, runExternalCmd(cmd)
+	return m, m.sessionCmd(cmd, ws, "", "")
+}
+
+// sessionEnv describes a workspace to everything started in its tmux session.
+// Resources from .vibe/wt.json are left out when it can't be loaded.
+func (m Model) sessionEnv(ws workspace.Workspace) []string {
+	env := []string{
+		"VIBEIT_PROJECT=" + m.projectName,
+		"VIBEIT_WORKSPACE=" + ws.Name,
+		"VIBEIT_BRANCH=" + ws.Branch,
+		"VIBEIT_BASE_BRANCH=" + workspace.BaseBranch(ws.Path),
+		"VIBEIT_MAIN_PATH=" + m.projectPath,
+		"VIBEIT_NOTES=" + m.notesPathFor(ws),
+	}
+	resources, err := workspace_init.ResourceEnv(m.projectPath, ws.Path)
+	if err != nil {
+		return append(env, fmt.Sprintf("VIBEIT_SLOT=%d", workspace_init.Slot(ws.Path)))
+	}
+	return append(env, resources...)
 }
 
 func (m Model) openSession(tabType mux.TabType) (tea.Model, tea.Cmd) {
@@ -607,9 +569,9 @@ func (m Model) openSession(tabType mux.TabType) (tea.Model, tea.Cmd) {
 	ws := m.workspaces[m.activeIdx]
 	sessionName := mux.SessionName(m.projectName, ws.Name, ws.Branch)
 
-	cmd := mux.OpenWithCommand(sessionName, ws.Path, tabType)
+	cmd := mux.OpenWithCommand(sessionName, ws.Path, tabType, m.sessionEnv(ws))
 	m.showTabPickerOnReturn = true
-	return m, runExternalCmd(cmd)
+	return m, m.sessionCmd(cmd, ws, string(tabType), tabType)
 }
 
 func (m Model) openSingleTab(tabType mux.TabType) (tea.Model, tea.Cmd) {
@@ -620,9 +582,9 @@ func (m Model) openSingleTab(tabType mux.TabType) (tea.Model, tea.Cmd) {
 
 	ws := m.workspaces[m.activeIdx]
 	sessionName := mux.SessionName(m.projectName, ws.Name, ws.Branch)
-	cmd := mux.GoToOrCreateSingleTabCmd(sessionName, ws.Path, tabType)
+	cmd := mux.GoToOrCreateSingleTabCmd(sessionName, ws.Path, tabType, m.sessionEnv(ws))
 	m.showTabPickerOnReturn = true
-	return m, runExternalCmd(cmd)
+	return m, m.sessionCmd(cmd, ws, string(tabType), "")
 }
 
 func (m Model) showTabPicker(filter mux.TabType) (tea.Model, tea.Cmd) {
@@ -661,12 +623,15 @@ func (m Model) showTabPicker(filter mux.TabType) (tea.Model, tea.Cmd) {
 
 func (m Model) openNotes() (tea.Model, tea.Cmd) {
 	ws := m.workspaces[m.activeIdx]
-	notesPath := notesPath(m.projectPath, m.projectName, ws.Branch)
 
-	// Open notes in nvim directly (without tmux for simplicity)
-	cmd := exec.Command("nvim", notesPath)
-	cmd.Dir = ws.Path
-	return m, runExternalCmd(cmd)
+	content, err := m.branchNotes(ws)
+	if err != nil {
+		m.statusMessage = errorStyle.Render(err.Error())
+		return m, nil
+	}
+
+	// Open notes in the editor directly (without tmux for simplicity)
+	return m.editNotes(m.notesPathFor(ws), content, ws.Path)
 }
 
 func (m Model) openWorkspaceConfig() (tea.Model, tea.Cmd) {
@@ -675,28 +640,305 @@ func (m Model) openWorkspaceConfig() (tea.Model, tea.Cmd) {
 		return m, nil
 	}
 
-	configPath, created, err := workspace_init.EnsureConfig(m.projectPath)
+	configPath, findings, err := workspace_init.EnsureConfig(m.projectPath)
 	if err != nil {
 		m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to open wt.json: %v", err))
 		return m, nil
 	}
-	if created {
-		m.statusMessage = successStyle.Render("Created .vibe/wt.json")
+	if findings != nil {
+		m.statusMessage = successStyle.Render("Created .vibe/wt.json from: " + strings.Join(findings, ", "))
 	}
 
-	cmd := exec.Command("nvim", configPath)
+	cmd := mux.EditorCmd(configPath)
 	cmd.Dir = m.projectPath
-	return m, runExternalCmd(cmd)
+	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return configEditedMsg{err}
+	})
+}
+
+func (m Model) proposeWorkspaceConfig() (tea.Model, tea.Cmd) {
+	if m.projectPath == "" {
+		m.statusMessage = errorStyle.Render("Cannot locate repository path")
+		return m, nil
+	}
+
+	wtConfig, findings := workspace_init.Propose(m.projectPath)
+	data, err := workspace_init.FormatConfig(wtConfig)
+	if err != nil {
+		m.statusMessage = errorStyle.Render(err.Error())
+		return m, nil
+	}
+
+	m.initConfig = wtConfig
+	m.initFindings = findings
+	m.initPreview = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	m.modal = modalInitConfig
+	return m, nil
+}
+
+func (m Model) handleInitConfigInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, confirmKeys.Cancel):
+		m.modal = modalNone
+		return m, nil
+
+	case key.Matches(msg, confirmKeys.Yes):
+		m.modal = modalNone
+		if _, err := workspace_init.WriteConfig(m.projectPath, m.initConfig); err != nil {
+			m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to write wt.json: %v", err))
+			return m, nil
+		}
+		m.wtConfigExists = true
+		m.statusMessage = successStyle.Render("Wrote .vibe/wt.json")
+		return m, nil
+	}
+
+	return m, nil
+}
+
+// runAction performs a main-view action by its keymap id
+func (m Model) runAction(id string) (tea.Model, tea.Cmd) {
+	switch id {
+	case "quit":
+		return m, tea.Quit
+
+	case "palette":
+		return m.openPalette()
+
+	case "grid":
+		return m.toggleGrid()
+
+	case "help":
+		return m.openHelp()
+
+	case "next_workspace":
+		if len(m.workspaces) > 0 {
+			return m.activate((m.activeIdx + 1) % len(m.workspaces))
+		}
+
+	case "prev_workspace":
+		if len(m.workspaces) > 0 {
+			return m.activate((m.activeIdx - 1 + len(m.workspaces)) % len(m.workspaces))
+		}
+
+	case "tabs":
+		// Show all managed tabs
+		if len(m.workspaces) > 0 {
+			return m.showTabPicker(mux.TabType(""))
+		}
+
+	case "terminal":
+		// Multi-instance terminal tabs
+		if len(m.workspaces) > 0 {
+			return m.showTabPicker(mux.TabTerminal)
+		}
+
+	case "lazygit":
+		// Single-instance lazygit
+		if len(m.workspaces) > 0 {
+			return m.openSingleTab(mux.TabLazygit)
+		}
+
+	case "claude":
+		// Multi-instance claude tabs
+		if len(m.workspaces) > 0 {
+			return m.showTabPicker(mux.TabClaude)
+		}
+
+	case "codex":
+		// Multi-instance codex tabs
+		if len(m.workspaces) > 0 {
+			return m.showTabPicker(mux.TabCodex)
+		}
+
+	case "nvim":
+		// Multi-instance nvim tabs
+		if len(m.workspaces) > 0 {
+			return m.showTabPicker(mux.TabNeovim)
+		}
+
+	case "notes":
+		if len(m.workspaces) > 0 {
+			return m.openNotes()
+		}
+
+	case "read_notes":
+		if len(m.workspaces) > 0 {
+			ws := m.workspaces[m.activeIdx]
+			if !ws.NotesExists {
+				m.statusMessage = mutedStyle.Render("No notes yet for " + ws.Branch)
+				return m, nil
+			}
+			return m.openMarkdown(m.notesPathFor(ws))
+		}
+
+	case "notes_picker":
+		return m.openNotesPicker()
+
+	case "todos":
+		return m.openTodos()
+
+	case "send_notes":
+		if len(m.workspaces) > 0 {
+			return m.openSendNotes()
+		}
+
+	case "notes_history":
+		if len(m.workspaces) > 0 {
+			ws := m.workspaces[m.activeIdx]
+			return m.openHistory(m.notesPathFor(ws), ws.Branch)
+		}
+
+	case "edit_config":
+		return m.openWorkspaceConfig()
+
+	case "init_config":
+		return m.proposeWorkspaceConfig()
+
+	case "open_md":
+		if len(m.workspaces) > 0 {
+			return m.openMdLuncher()
+		}
+
+	case "new_workspace":
+		m.modal = modalNewWorkspace
+		m.branchInput.SetValue("")
+		branches, err := workspace.ListBranches(m.workspaces[m.activeIdx].Path)
+		if err != nil {
+			branches = nil
+		}
+		m.baseBranchOptions = branches
+		m.baseBranchFiltered = nil
+		m.baseBranchIdx = 0
+		currentBranch := m.workspaces[m.activeIdx].Branch
+		m.baseBranchInput.SetValue("")
+		m.updateBaseBranchFilter()
+		m.baseBranchIdx = indexOfBranch(m.baseBranchFiltered, currentBranch)
+		m.branchInput.Focus()
+		m.baseBranchInput.Blur()
+		m.activeInput = 0
+		m.modalError = ""
+		return m, textinput.Blink
+
+	case "kill_session":
+		if len(m.workspaces) > 0 {
+			return m.confirmKillSession()
+		}
+
+	case "remove_workspace":
+		if len(m.workspaces) > 0 {
+			return m.confirmRemoveWorkspace()
+		}
+	}
+
+	return m, nil
+}
+
+func (m Model) confirmKillSession() (tea.Model, tea.Cmd) {
+	ws := m.workspaces[m.activeIdx]
+	sessionName := mux.SessionName(m.projectName, ws.Name, ws.Branch)
+	if !mux.SessionExists(sessionName) {
+		m.statusMessage = mutedStyle.Render("No tmux session for " + ws.Name)
+		return m, nil
+	}
+
+	windows, err := mux.ListWindows(sessionName)
+	if err != nil {
+		m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to list windows: %v", err))
+		return m, nil
+	}
+
+	m.killSession = sessionName
+	m.killWindows = windows
+	m.modal = modalKillSession
+	return m, nil
+}
+
+func (m Model) handleKillSessionInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, confirmKeys.Cancel):
+		m.modal = modalNone
+		return m, nil
+
+	case key.Matches(msg, confirmKeys.Snapshot):
+		m.modal = modalNone
+		dir, err := mux.SnapshotSession(m.killSession)
+		if err != nil {
+			m.statusMessage = errorStyle.Render(fmt.Sprintf("Snapshot failed, session kept: %v", err))
+			return m, nil
+		}
+		if err := mux.DeleteSession(m.killSession); err != nil {
+			m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to kill session: %v", err))
+			return m, nil
+		}
+		m.statusMessage = successStyle.Render(fmt.Sprintf("Killed session, scrollback saved to %s", dir))
+		return m, nil
+
+	case key.Matches(msg, confirmKeys.Yes):
+		m.modal = modalNone
+		if err := mux.DeleteSession(m.killSession); err != nil {
+			m.statusMessage = errorStyle.Render(fmt.Sprintf("Failed to kill session: %v", err))
+		} else {
+			m.statusMessage = successStyle.Render(fmt.Sprintf("Killed session: %s", m.killSession))
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m Model) confirmRemoveWorkspace() (tea.Model, tea.Cmd) {
+	ws := m.workspaces[m.activeIdx]
+	if !ws.IsSubWorkspace {
+		m.statusMessage = errorStyle.Render("The main repository cannot be removed")
+		return m, nil
+	}
+
+	wtConfig, err := workspace_init.LoadConfig(m.projectPath)
+	if err != nil {
+		m.statusMessage = errorStyle.Render(err.Error())
+		return m, nil
+	}
+
+	m.removeTeardown = wtConfig.Teardown
+	m.modal = modalRemoveWorkspace
+	return m, nil
+}
+
+func (m Model) handleRemoveWorkspaceInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, confirmKeys.Cancel):
+		m.modal = modalNone
+		return m, nil
+
+	case key.Matches(msg, confirmKeys.Yes):
+		m.modal = modalNone
+		ws := m.workspaces[m.activeIdx]
+		cmd := exec.Command(vibeitExecutable(), "remove", "--pause", ws.Path)
+		cmd.Dir = m.projectPath
+		return m, runExternalCmd(cmd)
+	}
+
+	return m, nil
+}
+
+// vibeitExecutable returns the path of the running binary for self-invocation
+func vibeitExecutable() string {
+	exePath, err := os.Executable()
+	if err != nil || exePath == "" {
+		return "vibeit"
+	}
+	return exePath
 }
 
 func (m Model) handleModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.modal {
 	case modalNewWorkspace:
-		switch msg.String() {
-		case "esc":
+		switch {
+		case key.Matches(msg, newWorkspaceKeys.Cancel):
 			m.modal = modalNone
 			return m, nil
-		case "enter":
+		case key.Matches(msg, newWorkspaceKeys.Create):
 			branchName := strings.TrimSpace(m.branchInput.Value())
 			if branchName == "" {
 				m.modalError = "Branch name cannot be empty"
@@ -715,17 +957,17 @@ func (m Model) handleModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				baseBranch = m.workspaces[m.activeIdx].Branch
 			}
 			return m, createWorkspace(m.projectPath, branchName, baseBranch)
-		case "up", "k":
+		case key.Matches(msg, newWorkspaceKeys.Up):
 			if m.activeInput == 1 && m.baseBranchIdx > 0 {
 				m.baseBranchIdx--
 				return m, nil
 			}
-		case "down", "j":
+		case key.Matches(msg, newWorkspaceKeys.Down):
 			if m.activeInput == 1 && m.baseBranchIdx < len(m.baseBranchFiltered)-1 {
 				m.baseBranchIdx++
 				return m, nil
 			}
-		case "tab", "shift+tab":
+		case key.Matches(msg, newWorkspaceKeys.Switch):
 			if m.activeInput == 0 {
 				m.activeInput = 1
 				m.branchInput.Blur()
@@ -753,11 +995,38 @@ func (m Model) handleModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case modalTabTypePicker:
 		return m.handleTabTypePickerInput(msg)
 
-	case modalMdLuncherFolder:
-		return m.handleMdLuncherFolderInput(msg)
+	case modalMdLuncher:
+		return m.handleMdLuncherInput(msg)
+
+	case modalRemoveWorkspace:
+		return m.handleRemoveWorkspaceInput(msg)
+
+	case modalInitConfig:
+		return m.handleInitConfigInput(msg)
+
+	case modalKillSession:
+		return m.handleKillSessionInput(msg)
+
+	case modalPalette:
+		return m.handlePaletteInput(msg)
+
+	case modalHelp:
+		return m.handleHelpInput(msg)
+
+	case modalMarkdown:
+		return m.handleMarkdownInput(msg)
+
+	case modalNotesPicker:
+		return m.handleNotesPickerInput(msg)
+
+	case modalTodos:
+		return m.handleTodosInput(msg)
 
-	case modalMdLuncherSelect:
-		return m.handleMdLuncherSelectInput(msg)
+	case modalHistory:
+		return m.handleHistoryInput(msg)
+
+	case modalSendNotes:
+		return m.handleSendNotesInput(msg)
 	}
 
 	return m, nil
@@ -773,25 +1042,25 @@ func (m Model) tabPickerNewLabel() string {
 func (m Model) handleTabPickerInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	ws := m.workspaces[m.activeIdx]
 
-	switch msg.String() {
-	case "esc":
+	switch {
+	case key.Matches(msg, tabPickerKeys.Close):
 		m.modal = modalNone
 		return m, nil
 
-	case "up", "k":
+	case key.Matches(msg, tabPickerKeys.Up):
 		if m.tabPickerIdx > 0 {
 			m.tabPickerIdx--
 		}
 		return m, nil
 
-	case "down", "j":
+	case key.Matches(msg, tabPickerKeys.Down):
 		// +1 for "New" option
 		if m.tabPickerIdx < len(m.tabPickerTabs) {
 			m.tabPickerIdx++
 		}
 		return m, nil
 
-	case "enter":
+	case key.Matches(msg, tabPickerKeys.Open):
 		m.modal = modalNone
 
 		// Check if "New" option is selected (last item)
@@ -803,19 +1072,19 @@ func (m Model) handleTabPickerInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 
 			tabName := mux.NextTabName(m.tabPickerTabs, m.tabPickerFilter)
-			cmd := mux.NewTabCmd(m.tabPickerSession, ws.Path, tabName, m.tabPickerFilter)
+			cmd := mux.NewTabCmd(m.tabPickerSession, ws.Path, tabName, m.tabPickerFilter, m.sessionEnv(ws))
 			m.showTabPickerOnReturn = true
-			return m, runExternalCmd(cmd)
+			return m, m.sessionCmd(cmd, ws, tabName, m.tabPickerFilter)
 		}
 
 		// Go to selected existing tab
 		tabName := m.tabPickerTabs[m.tabPickerIdx]
-		cmd := mux.GoToTabCmd(m.tabPickerSession, ws.Path, tabName)
+		cmd := mux.GoToTabCmd(m.tabPickerSession, ws.Path, tabName, m.sessionEnv(ws))
 		m.showTabPickerOnReturn = true
-		return m, runExternalCmd(cmd)
+		return m, m.sessionCmd(cmd, ws, tabName, "")
 
 	// Quick select by number
-	case "1", "2", "3", "4", "5", "6", "7", "8", "9":
+	case key.Matches(msg, tabPickerKeys.Position):
 		idx := int(msg.String()[0] - '1')
 		// +1 because last item is "New"
 		if idx <= len(m.tabPickerTabs) {
@@ -833,32 +1102,32 @@ func (m Model) handleTabTypePickerInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	ws := m.workspaces[m.activeIdx]
 	options := tabTypeOptions()
 
-	switch msg.String() {
-	case "esc":
+	switch {
+	case key.Matches(msg, tabTypePickerKeys.Back):
 		m.modal = modalTabPicker
 		return m, nil
 
-	case "up", "k":
+	case key.Matches(msg, tabTypePickerKeys.Up):
 		if m.tabTypePickerIdx > 0 {
 			m.tabTypePickerIdx--
 		}
 		return m, nil
 
-	case "down", "j":
+	case key.Matches(msg, tabTypePickerKeys.Down):
 		if m.tabTypePickerIdx < len(options)-1 {
 			m.tabTypePickerIdx++
 		}
 		return m, nil
 
-	case "enter":
+	case key.Matches(msg, tabTypePickerKeys.Create):
 		m.modal = modalNone
 		tabType := options[m.tabTypePickerIdx]
 		tabName := mux.NextTabName(m.tabPickerTabs, tabType)
-		cmd := mux.NewTabCmd(m.tabPickerSession, ws.Path, tabName, tabType)
+		cmd := mux.NewTabCmd(m.tabPickerSession, ws.Path, tabName, tabType, m.sessionEnv(ws))
 		m.showTabPickerOnReturn = true
-		return m, runExternalCmd(cmd)
+		return m, m.sessionCmd(cmd, ws, tabName, tabType)
 
-	case "1", "2", "3", "4":
+	case key.Matches(msg, tabTypePickerKeys.Position):
 		idx := int(msg.String()[0] - '1')
 		if idx < len(options) {
 			m.tabTypePickerIdx = idx
@@ -870,102 +1139,6 @@ func (m Model) handleTabTypePickerInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
-func (m Model) handleMdLuncherFolderInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.modal = modalNone
-		return m, nil
-
-	case "enter":
-		folder := strings.TrimSpace(m.mdLuncherFolderInput.Value())
-		if folder == "" {
-			folder = "docs"
-		}
-
-		ws := m.workspaces[m.activeIdx]
-		folderPath := filepath.Join(ws.Path, folder)
-
-		files, err := scanMarkdownFiles(folderPath)
-		if err != nil {
-			m.mdLuncherError = fmt.Sprintf("Folder '%s' not found", folder)
-			return m, nil
-		}
-
-		if len(files) == 0 {
-			m.mdLuncherError = fmt.Sprintf("No .md files found in '%s'", folder)
-			return m, nil
-		}
-
-		m.mdLuncherFiles = files
-		m.mdLuncherIdx = 0
-		m.mdLuncherError = ""
-		m.modal = modalMdLuncherSelect
-		return m, nil
-
-	default:
-		var cmd tea.Cmd
-		m.mdLuncherFolderInput, cmd = m.mdLuncherFolderInput.Update(msg)
-		m.mdLuncherError = ""
-		return m, cmd
-	}
-}
-
-func (m Model) handleMdLuncherSelectInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "esc":
-		m.modal = modalMdLuncherFolder
-		return m, nil
-
-	case "up", "k":
-		if m.mdLuncherIdx > 0 {
-			m.mdLuncherIdx--
-		}
-		return m, nil
-
-	case "down", "j":
-		if m.mdLuncherIdx < len(m.mdLuncherFiles)-1 {
-			m.mdLuncherIdx++
-		}
-		return m, nil
-
-	case "enter":
-		if len(m.mdLuncherFiles) == 0 {
-			return m, nil
-		}
-
-		selectedFile := m.mdLuncherFiles[m.mdLuncherIdx]
-		m.modal = modalNone
-
-		cmd := exec.Command("omarchy-launch-browser", selectedFile)
-		return m, runExternalCmd(cmd)
-	}
-
-	return m, nil
-}
-
-func scanMarkdownFiles(dir string) ([]string, error) {
-	info, err := os.Stat(dir)
-	if err != nil {
-		return nil, err
-	}
-	if !info.IsDir() {
-		return nil, fmt.Errorf("not a directory")
-	}
-
-	var files []string
-	err = filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return nil
-		}
-		if !info.IsDir() && strings.HasSuffix(strings.ToLower(info.Name()), ".md") {
-			files = append(files, path)
-		}
-		return nil
-	})
-
-	return files, err
-}
-
 func (m Model) View() string {
 	if !m.ready {
 		return "Loading..."
@@ -975,13 +1148,21 @@ func (m Model) View() string {
 		return fmt.Sprintf("\n  Error: %v\n\n  Run 'vibeit' in a git repository.\n", m.err)
 	}
 
+	if m.modal == modalMarkdown {
+		return m.renderMarkdownView()
+	}
+
 	var b strings.Builder
 
 	b.WriteString(m.renderTopBar())
 	b.WriteString("\n")
 
 	contentHeight := m.height - 4
-	b.WriteString(m.renderMainContent(contentHeight))
+	if m.gridMode {
+		b.WriteString(m.renderGrid(contentHeight))
+	} else {
+		b.WriteString(m.renderMainContent(contentHeight))
+	}
 
 	b.WriteString("\n")
 	b.WriteString(m.renderFooter())
@@ -993,35 +1174,59 @@ func (m Model) View() string {
 	return b.String()
 }
 
-func (m Model) renderWithModal(background string) string {
-	var modal string
-
+// renderModal renders the open modal on its own
+func (m Model) renderModal() string {
 	switch m.modal {
 	case modalNewWorkspace:
-		modal = m.renderNewWorkspaceModal()
+		return m.renderNewWorkspaceModal()
 	case modalTabPicker:
-		modal = m.renderTabPickerModal()
+		return m.renderTabPickerModal()
 	case modalTabTypePicker:
-		modal = m.renderTabTypePickerModal()
-	case modalMdLuncherFolder:
-		modal = m.renderMdLuncherFolderModal()
-	case modalMdLuncherSelect:
-		modal = m.renderMdLuncherSelectModal()
-	}
-
-	lines := strings.Split(background, "\n")
-	modalLines := strings.Split(modal, "\n")
+		return m.renderTabTypePickerModal()
+	case modalMdLuncher:
+		return m.renderMdLuncherModal()
+	case modalRemoveWorkspace:
+		return m.renderRemoveWorkspaceModal()
+	case modalInitConfig:
+		return m.renderInitConfigModal()
+	case modalKillSession:
+		return m.renderKillSessionModal()
+	case modalPalette:
+		return m.renderPaletteModal()
+	case modalHelp:
+		return m.renderHelpModal()
+	case modalNotesPicker:
+		return m.renderNotesPickerModal()
+	case modalTodos:
+		return m.renderTodosModal()
+	case modalHistory:
+		return m.renderHistoryModal()
+	case modalSendNotes:
+		return m.renderSendNotesModal()
+	}
+	return ""
+}
 
-	startLine := (len(lines) - len(modalLines)) / 2
+// modalPosition returns the top line and left column a modal is drawn at
+func (m Model) modalPosition(screenLines int, modal string) (int, int) {
+	startLine := (screenLines - lipgloss.Height(modal)) / 2
 	if startLine < 2 {
 		startLine = 2
 	}
 
-	modalWidth := lipgloss.Width(modal)
-	leftPad := (m.width - modalWidth) / 2
+	leftPad := (m.width - lipgloss.Width(modal)) / 2
 	if leftPad < 0 {
 		leftPad = 0
 	}
+	return startLine, leftPad
+}
+
+func (m Model) renderWithModal(background string) string {
+	modal := m.renderModal()
+
+	lines := strings.Split(background, "\n")
+	modalLines := strings.Split(modal, "\n")
+	startLine, leftPad := m.modalPosition(len(lines), modal)
 
 	for i, modalLine := range modalLines {
 		lineIdx := startLine + i
@@ -1164,85 +1369,122 @@ func (m Model) renderTabTypePickerModal() string {
 	return modalStyle.Render(content.String())
 }
 
-func (m Model) renderMdLuncherFolderModal() string {
-	var content strings.Builder
+func (m Model) renderRemoveWorkspaceModal() string {
+	ws := m.workspaces[m.activeIdx]
+	labelWidth := 8
+	width := 44
 
-	content.WriteString(modalTitleStyle.Render("Open Markdown"))
+	var content strings.Builder
+	content.WriteString(modalTitleStyle.Render("Remove Workspace"))
+	content.WriteString("\n\n")
+	content.WriteString(formatLabelValue("Name", ws.Name, labelWidth, width))
+	content.WriteString("\n")
+	content.WriteString(formatLabelValue("Branch", ws.Branch, labelWidth, width))
+	content.WriteString("\n")
+	content.WriteString(formatLabelValue("Path", truncateMiddle(ws.Path, width-labelWidth-1), labelWidth, width))
 	content.WriteString("\n\n")
-	content.WriteString("Folder to scan:\n")
-	content.WriteString(m.mdLuncherFolderInput.View())
 
-	if m.mdLuncherError != "" {
-		content.WriteString("\n\n")
-		content.WriteString(errorStyle.Render(m.mdLuncherError))
+	content.WriteString(sectionTitleStyle.Render("TEARDOWN"))
+	content.WriteString("\n")
+	if len(m.removeTeardown) == 0 {
+		content.WriteString(mutedStyle.Render("  (none)"))
+	}
+	for i, cmdStr := range m.removeTeardown {
+		content.WriteString(modalItemStyle.Render("  " + truncateText(cmdStr, width-2)))
+		if i < len(m.removeTeardown)-1 {
+			content.WriteString("\n")
+		}
 	}
 
-	content.WriteString("\n\n")
-	content.WriteString(modalHintStyle.Render("Enter to scan • Esc to cancel"))
+	if ws.IsDirty {
+		content.WriteString("\n\n")
+		content.WriteString(errorStyle.Render("Uncommitted changes will be lost!"))
+	}
+	if ws.Ahead > 0 {
+		content.WriteString("\n")
+		content.WriteString(errorStyle.Render(fmt.Sprintf("%d unpushed commit(s) will be lost!", ws.Ahead)))
+	}
 
+	content.WriteString("\n")
+	content.WriteString(modalHintStyle.Render("y to remove • Esc to cancel"))
 	return modalStyle.Render(content.String())
 }
 
-func (m Model) renderMdLuncherSelectModal() string {
-	var content strings.Builder
+func (m Model) renderKillSessionModal() string {
+	width := 44
 
-	content.WriteString(modalTitleStyle.Render(fmt.Sprintf("Select file (%d found)", len(m.mdLuncherFiles))))
+	var content strings.Builder
+	content.WriteString(modalTitleStyle.Render("Kill Session"))
+	content.WriteString("\n\n")
+	content.WriteString(mutedStyle.Render(truncateText(m.killSession, width)))
 	content.WriteString("\n\n")
 
-	// Show a scrolling window of files
-	maxVisible := 10
-	total := len(m.mdLuncherFiles)
-
-	start := 0
-	if m.mdLuncherIdx >= maxVisible {
-		start = m.mdLuncherIdx - maxVisible + 1
-	}
-	end := start + maxVisible
-	if end > total {
-		end = total
-		start = end - maxVisible
-		if start < 0 {
-			start = 0
-		}
+	content.WriteString(sectionTitleStyle.Render("WINDOWS"))
+	content.WriteString("\n")
+	for _, w := range m.killWindows {
+		name := truncateText(w.Name, 14)
+		running := truncateText(strings.Join(w.Commands, ", "), width-18)
+		content.WriteString(modalItemStyle.Render(fmt.Sprintf("  %-14s  %s", name, running)))
+		content.WriteString("\n")
 	}
 
-	if start > 0 {
-		content.WriteString(helpTextStyle.Render(fmt.Sprintf("  ...%d above", start)))
+	content.WriteString("\n")
+	content.WriteString(errorStyle.Render("Running agents and unsaved buffers will be lost!"))
+	content.WriteString("\n")
+	content.WriteString(modalHintStyle.Render("y to kill • s to snapshot then kill • Esc to cancel"))
+	return modalStyle.Render(content.String())
+}
+
+func (m Model) renderInitConfigModal() string {
+	var content strings.Builder
+
+	content.WriteString(modalTitleStyle.Render("Init .vibe/wt.json"))
+	content.WriteString("\n\n")
+	for _, finding := range m.initFindings {
+		content.WriteString(modalItemStyle.Render("• " + truncateText(finding, 42)))
 		content.WriteString("\n")
 	}
+	content.WriteString("\n")
 
-	for i := start; i < end; i++ {
-		// Show relative path from workspace for readability
-		displayName := filepath.Base(m.mdLuncherFiles[i])
-		// Include parent folder if nested
-		dir := filepath.Dir(m.mdLuncherFiles[i])
-		parentDir := filepath.Base(dir)
-		if parentDir != "." && parentDir != "/" {
-			displayName = parentDir + "/" + displayName
-		}
-
-		prefix := "  "
-		if i == m.mdLuncherIdx {
-			prefix = "> "
-			content.WriteString(modalItemSelectedStyle.Render(prefix + displayName))
-		} else {
-			content.WriteString(modalItemStyle.Render(prefix + displayName))
+	maxLines := m.height - len(m.initFindings) - 14
+	if maxLines < 5 {
+		maxLines = 5
+	}
+	for i, line := range m.initPreview {
+		if i == maxLines {
+			content.WriteString(helpTextStyle.Render(fmt.Sprintf("...%d more lines", len(m.initPreview)-i)))
+			content.WriteString("\n")
+			break
 		}
+		content.WriteString(mutedStyle.Render(truncateText(line, 44)))
 		content.WriteString("\n")
 	}
 
-	if end < total {
-		content.WriteString(helpTextStyle.Render(fmt.Sprintf("  ...%d more", total-end)))
+	if m.wtConfigExists {
+		content.WriteString("\n")
+		content.WriteString(errorStyle.Render("Existing wt.json will be overwritten"))
 		content.WriteString("\n")
 	}
 
-	content.WriteString(modalHintStyle.Render("Enter to open • Esc to go back"))
+	content.WriteString(modalHintStyle.Render("y to write • Esc to cancel"))
 	return modalStyle.Render(content.String())
 }
 
 func (m Model) renderTopBar() string {
 	projectPart := projectNameStyle.Render(m.projectName)
+	tabsPart := strings.Join(m.topBarTabs(), " ")
+	content := projectPart + "  " + tabsPart
+
+	padding := m.width - lipgloss.Width(content) - 2
+	if padding < 0 {
+		padding = 0
+	}
+
+	return topBarStyle.Width(m.width).Render(content + strings.Repeat(" ", padding))
+}
 
+// topBarTabs renders one top bar tab per workspace
+func (m Model) topBarTabs() []string {
 	var tabs []string
 	for i, ws := range m.workspaces {
 		// Show branch name with folder reference for sub-workspaces
@@ -1261,6 +1503,9 @@ func (m Model) renderTopBar() string {
 		if ws.Ahead > 0 || ws.Behind > 0 {
 			name += fmt.Sprintf(" ↑%d↓%d", ws.Ahead, ws.Behind)
 		}
+		if ws.OpenTodos > 0 {
+			name += fmt.Sprintf(" ☐%d", ws.OpenTodos)
+		}
 
 		// Show tmux session indicator
 		sessionName := mux.SessionName(m.projectName, ws.Name, ws.Branch)
@@ -1277,15 +1522,7 @@ func (m Model) renderTopBar() string {
 		}
 	}
 
-	tabsPart := strings.Join(tabs, " ")
-	content := projectPart + "  " + tabsPart
-
-	padding := m.width - lipgloss.Width(content) - 2
-	if padding < 0 {
-		padding = 0
-	}
-
-	return topBarStyle.Width(m.width).Render(content + strings.Repeat(" ", padding))
+	return tabs
 }
 
 func (m Model) renderMainContent(height int) string {
@@ -1379,7 +1616,7 @@ func (m Model) renderWorkspacePanel(ws workspace.Workspace, width int) string {
 	content.WriteString("\n\n")
 	content.WriteString(sectionTitleStyle.Render("NOTES"))
 	content.WriteString("\n")
-	content.WriteString(renderNotes(ws.NotesPreview, ws.NotesExists, width))
+	content.WriteString(renderNotes(ws.NotesPreview, ws.NotesExists, width, m.notesScroll))
 
 	return content.String()
 }
@@ -1419,7 +1656,7 @@ func (m Model) renderGitPanel(ws workspace.Workspace, width int) string {
 	content.WriteString("\n\n")
 	content.WriteString(sectionTitleStyle.Render("COMMITS"))
 	content.WriteString("\n")
-	content.WriteString(renderCommits(ws.RecentCommits, width))
+	content.WriteString(renderCommits(ws.RecentCommits, width, m.commitScroll))
 
 	return content.String()
 }
@@ -1438,7 +1675,7 @@ func formatLabelLine(label, value string, labelWidth int) string {
 	return labelStyle.Render(labelText) + " " + value
 }
 
-func renderNotes(lines []string, exists bool, width int) string {
+func renderNotes(lines []string, exists bool, width, offset int) string {
 	if !exists {
 		return mutedStyle.Render("  (no notes yet)") + "\n" + helpTextStyle.Render("  Press n to create")
 	}
@@ -1446,8 +1683,10 @@ func renderNotes(lines []string, exists bool, width int) string {
 		return mutedStyle.Render("  (empty)")
 	}
 
+	start, end := scrollWindow(len(lines), offset, visibleNotes)
 	var content strings.Builder
-	for i, line := range lines {
+	for i := start; i < end; i++ {
+		line := lines[i]
 		prefix := fmt.Sprintf("%2d ", i+1)
 		available := width - len(prefix)
 		if available < 0 {
@@ -1455,20 +1694,26 @@ func renderNotes(lines []string, exists bool, width int) string {
 		}
 		content.WriteString(mutedStyle.Render(prefix))
 		content.WriteString(truncateText(line, available))
-		if i < len(lines)-1 {
+		if i < end-1 {
 			content.WriteString("\n")
 		}
 	}
+	if end < len(lines) {
+		content.WriteString("\n")
+		content.WriteString(helpTextStyle.Render(fmt.Sprintf("   ...%d more", len(lines)-end)))
+	}
 	return content.String()
 }
 
-func renderCommits(commits []string, width int) string {
+func renderCommits(commits []string, width, offset int) string {
 	if len(commits) == 0 {
 		return mutedStyle.Render("  (none)")
 	}
 
+	start, end := scrollWindow(len(commits), offset, visibleCommits)
 	var content strings.Builder
-	for i, line := range commits {
+	for i := start; i < end; i++ {
+		line := commits[i]
 		hash, msg := splitCommitLine(line)
 		prefix := mutedStyle.Render("- ")
 		available := width - 2 - len(hash) - 1
@@ -1481,13 +1726,19 @@ func renderCommits(commits []string, width int) string {
 			content.WriteString(" ")
 			content.WriteString(mutedStyle.Render(truncateText(msg, available)))
 		}
-		if i < len(commits)-1 {
+		if i < end-1 {
 			content.WriteString("\n")
 		}
 	}
 	return content.String()
 }
 
+// scrollWindow clamps offset so a window of size rows stays within total
+func scrollWindow(total, offset, size int) (int, int) {
+	start := max(min(offset, total-size), 0)
+	return start, min(start+size, total)
+}
+
 func splitCommitLine(line string) (string, string) {
 	parts := strings.SplitN(strings.TrimSpace(line), " ", 2)
 	if len(parts) == 0 || parts[0] == "" {
@@ -1634,30 +1885,12 @@ func isManagedTabName(name string) bool {
 	return false
 }
 
-func notesPath(projectPath, projectName, _ string) string {
-	parentDir := filepath.Dir(projectPath)
-	notesFile := fmt.Sprintf("%s.md", projectName)
-	return filepath.Join(parentDir, notesFile)
-}
-
-func notesPreview(projectPath, projectName, branch string) (bool, []string) {
-	path := notesPath(projectPath, projectName, branch)
-	file, err := os.Open(path)
-	if err != nil {
-		return false, nil
-	}
-	defer file.Close()
-
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-		if len(lines) >= 10 {
-			break
-		}
-	}
-	return true, lines
-}
+// Main view panes show a window of these; the mouse wheel scrolls the rest
+const (
+	visibleCommits  = 5
+	visibleNotes    = 10
+	maxNotesPreview = 200
+)
 
 func statusText(ws workspace.Workspace) string {
 	if ws.IsDirty {
@@ -1676,31 +1909,7 @@ func extractWtNumber(folderName string) string {
 }
 
 func (m Model) renderFooter() string {
-	bindings := []struct {
-		key  string
-		desc string
-	}{
-		{"g", "lazygit"},
-		{"c", "claude"},
-		{"x", "codex"},
-		{"v", "nvim"},
-		{"t", "term"},
-		{"n", "notes"},
-		{"o", "open md"},
-		{"e", "wt.json"},
-		{"w", "new ws"},
-		{"k", "kill ses"},
-		{"enter", "tabs"},
-		{"q", "quit"},
-	}
-
-	var parts []string
-	for _, b := range bindings {
-		parts = append(parts,
-			footerKeyStyle.Render(b.key)+footerDescStyle.Render(b.desc),
-		)
-	}
-
+	parts, _ := m.footerParts()
 	content := strings.Join(parts, " ")
 	padding := m.width - lipgloss.Width(content) - 2
 	if padding < 0 {
@@ -1710,8 +1919,56 @@ func (m Model) renderFooter() string {
 	return footerStyle.Width(m.width).Render(content + strings.Repeat(" ", padding))
 }
 
+// footerParts renders the footer entries that fit, with their action ids.
+// Bindings that don't fit are dropped rather than wrapping onto a second
+// line; everything stays reachable from the command palette.
+func (m Model) footerParts() ([]string, []string) {
+	var parts, ids []string
+	available := m.width - 2
+	for _, b := range keys.footerBindings() {
+		part := footerKeyStyle.Render(b.key) + footerDescStyle.Render(b.label)
+		if lipgloss.Width(strings.Join(append(parts, part), " ")) > available {
+			break
+		}
+		parts = append(parts, part)
+		ids = append(ids, b.id)
+	}
+	return parts, ids
+}
+
 func Run() error {
-	p := tea.NewProgram(initialModel(), tea.WithAltScreen())
-	_, err := p.Run()
+	projectPath, _ := workspace.GetProjectPath()
+	cfg, err := config.Load(projectPath)
+	if err != nil {
+		return err
+	}
+	mux.Configure(cfg.Settings)
+
+	// Inside tmux, the dashboard may move to its own window or a popup
+	workDir, _ := os.Getwd()
+	if moved, err := mux.OpenDashboard(cfg.Tmux.Dashboard, workDir); moved || err != nil {
+		return err
+	}
+	mux.EnterDashboard(workDir)
+	defer mux.LeaveDashboard()
+
+	keys, err = newKeyMap(cfg.Settings.Keys)
+	if err != nil {
+		return err
+	}
+
+	theme, err := loadTheme(cfg.Settings)
+	if err != nil {
+		return err
+	}
+	applyTheme(theme)
+
+	options := []tea.ProgramOption{tea.WithAltScreen()}
+	if cfg.Mouse {
+		options = append(options, tea.WithMouseCellMotion())
+	}
+
+	p := tea.NewProgram(initialModel(cfg.Settings), options...)
+	_, err = p.Run()
 	return err
 }
@@ -5,6 +5,9 @@ import (
 	"strings"
 )
 
+// BaseBranchKey is the git config key recording the branch a workspace was created from
+const BaseBranchKey = "vibeit.baseBranch"
+
 // UpdateGitStatus refreshes git-related fields for a workspace.
 func UpdateGitStatus(ws Workspace) Workspace {
 	if branch := gitBranch(ws.Path); branch != "" {
@@ -20,12 +23,24 @@ func UpdateGitStatus(ws Workspace) Workspace {
 	if stashCount, ok := gitStashCount(ws.Path); ok {
 		ws.StashCount = stashCount
 	}
-	if commits, ok := gitRecentCommits(ws.Path, 5); ok {
+	if head := gitHead(ws.Path); head != "" {
+		ws.Head = head
+	}
+	if commits, ok := gitRecentCommits(ws.Path, 30); ok {
 		ws.RecentCommits = commits
 	}
 	return ws
 }
 
+// BaseBranch returns the branch a workspace was created from, or "" when unknown
+func BaseBranch(path string) string {
+	out, err := runGitCommand(path, "config", "--get", BaseBranchKey)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
 func gitBranch(path string) string {
 	out, err := runGitCommand(path, "rev-parse", "--abbrev-ref", "HEAD")
 	if err != nil {
@@ -34,6 +49,21 @@ func gitBranch(path string) string {
 	return strings.TrimSpace(out)
 }
 
+// gitHead returns the full hash of the commit HEAD points at
+func gitHead(path string) string {
+	out, err := runGitCommand(path, "rev-parse", "--verify", "--quiet", "HEAD")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(out)
+}
+
+// IsAncestor reports whether ancestor is reachable from commit in the repo at path
+func IsAncestor(path, ancestor, commit string) bool {
+	_, err := runGitCommand(path, "merge-base", "--is-ancestor", ancestor, commit)
+	return err == nil
+}
+
 func gitDirty(path string) (bool, bool) {
 	out, err := runGitCommand(path, "status", "--porcelain")
 	if err != nil {
@@ -19,9 +19,12 @@ type Workspace struct {
 	Ahead          int
 	Behind         int
 	StashCount     int
+	Head           string
 	RecentCommits  []string
+	NotesPath      string
 	NotesExists    bool
 	NotesPreview   []string
+	OpenTodos      int
 }
 
 // Detect finds the main repo and all sibling workspaces from the current directory
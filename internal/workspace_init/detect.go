@@ -0,0 +1,132 @@
+package workspace_init
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Propose inspects a repository and suggests a .vibe/wt.json for its stack.
+// It returns the config together with a human readable list of findings.
+func Propose(repoPath string) (Config, []string) {
+	config := Config{
+		Before: []string{},
+		Copy:   []string{},
+		After:  []string{},
+	}
+	var findings []string
+
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(repoPath, name))
+		return err == nil
+	}
+	use := func(finding string, copyItems []string, after string) {
+		findings = append(findings, finding)
+		for _, item := range copyItems {
+			if exists(item) {
+				config.Copy = append(config.Copy, item)
+			}
+		}
+		if after != "" {
+			config.After = append(config.After, after)
+		}
+	}
+
+	// Environment files
+	switch {
+	case exists(".env"):
+		use(".env found: copied into each workspace", []string{".env"}, "")
+	case exists(".env.example"):
+		use(".env.example found: used as the workspace .env", nil, "cp -n .env.example .env")
+	}
+
+	// JavaScript
+	if exists("package.json") {
+		switch {
+		case exists("pnpm-lock.yaml"):
+			use("package.json with pnpm-lock.yaml", []string{"node_modules"}, "pnpm install")
+		case exists("yarn.lock"):
+			use("package.json with yarn.lock", []string{"node_modules"}, "yarn install")
+		case exists("bun.lock"), exists("bun.lockb"):
+			use("package.json with bun lockfile", []string{"node_modules"}, "bun install")
+		case exists("package-lock.json"):
+			use("package.json with package-lock.json", []string{"node_modules"}, "npm ci")
+		default:
+			use("package.json without lockfile", []string{"node_modules"}, "npm install")
+		}
+	}
+
+	// PHP
+	if exists("composer.json") {
+		use("composer.json", []string{"vendor"}, "composer install")
+	}
+
+	// Go
+	if exists("go.mod") {
+		use("go.mod", nil, "go mod download")
+	}
+
+	// Ruby
+	if exists("Gemfile") {
+		use("Gemfile", nil, "bundle install")
+	}
+
+	// Python
+	if exists("pyproject.toml") {
+		switch {
+		case exists("uv.lock"):
+			use("pyproject.toml with uv.lock", nil, "uv sync")
+		case exists("poetry.lock"):
+			use("pyproject.toml with poetry.lock", nil, "poetry install")
+		default:
+			use("pyproject.toml", nil, "")
+		}
+	} else if exists("requirements.txt") {
+		use("requirements.txt", nil, "")
+	}
+
+	// Docker
+	for _, name := range []string{"compose.yaml", "compose.yml", "docker-compose.yml", "docker-compose.yaml"} {
+		if exists(name) {
+			findings = append(findings, name+": containers stopped on removal")
+			config.Teardown = append(config.Teardown, "docker compose down")
+			break
+		}
+	}
+
+	if len(findings) == 0 {
+		findings = append(findings, "no known stack detected")
+	}
+
+	return config, findings
+}
+
+// FormatConfig encodes a config the way .vibe/wt.json is written on disk
+func FormatConfig(config Config) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	if err := encoder.Encode(config); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// WriteConfig writes .vibe/wt.json, replacing any existing file
+func WriteConfig(repoPath string, config Config) (string, error) {
+	data, err := FormatConfig(config)
+	if err != nil {
+		return "", err
+	}
+
+	path := ConfigPath(repoPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,168 @@
+package workspace_init
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+// Resource types supported in the "resources" section of .vibe/wt.json
+const (
+	ResourcePort   = "port"
+	ResourceNumber = "number"
+	ResourceName   = "name"
+)
+
+// Resource declares a value that must be unique per workspace, such as a dev
+// server port, a database name or a Redis DB number.
+//
+//	"resources": {
+//	    "APP_PORT": {"type": "port", "base": 3000},
+//	    "REDIS_DB": {"type": "number", "base": 0},
+//	    "DB_NAME":  {"type": "name", "prefix": "myapp"}
+//	}
+//
+// Values are derived from the workspace slot (0 for the main repo, N for
+// {project}-wt-N), so they are stable and need no bookkeeping to release.
+type Resource struct {
+	Type   string `json:"type"`
+	Base   int    `json:"base,omitempty"`
+	Step   int    `json:"step,omitempty"`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+var (
+	slotPattern         = regexp.MustCompile(`-wt-(\d+)$`)
+	resourceNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// Slot returns the workspace number for a {projectName}-wt-N path, or 0 for the main repo
+func Slot(workspacePath string) int {
+	matches := slotPattern.FindStringSubmatch(filepath.Base(filepath.Clean(workspacePath)))
+	if matches == nil {
+		return 0
+	}
+	num, _ := strconv.Atoi(matches[1])
+	return num
+}
+
+// Allocate computes the value of every declared resource for a workspace slot
+func (c Config) Allocate(slot int) (map[string]string, error) {
+	values := make(map[string]string, len(c.Resources))
+	for name, res := range c.Resources {
+		if !resourceNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("invalid resource name %q", name)
+		}
+		value, err := res.value(slot)
+		if err != nil {
+			return nil, fmt.Errorf("resource %s: %w", name, err)
+		}
+		values[name] = value
+	}
+	return values, nil
+}
+
+// CheckOverlaps reports port and number resources of the same type whose
+// values collide across slots, e.g. APP_PORT 3000 and VITE_PORT 3001 both
+// hand out 3001
+func (c Config) CheckOverlaps() error {
+	names := make([]string, 0, len(c.Resources))
+	for name := range c.Resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i, a := range names {
+		for _, b := range names[i+1:] {
+			ra, rb := c.Resources[a], c.Resources[b]
+			if ra.Type != rb.Type || (ra.Type != ResourcePort && ra.Type != ResourceNumber) {
+				continue
+			}
+			if slotA, slotB, ok := ra.collides(rb); ok {
+				return fmt.Errorf("resources %s and %s overlap: both use %d (slots %d and %d)",
+					a, b, ra.number(slotA), slotA, slotB)
+			}
+		}
+	}
+	return nil
+}
+
+// collides returns the first pair of slots at which two numeric resources
+// hand out the same value
+func (r Resource) collides(other Resource) (int, int, bool) {
+	for slotA := 0; slotA <= maxWorkspaces; slotA++ {
+		for slotB := 0; slotB <= maxWorkspaces; slotB++ {
+			if r.number(slotA) == other.number(slotB) {
+				return slotA, slotB, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// number is the value of a port or number resource for a slot
+func (r Resource) number(slot int) int {
+	step := r.Step
+	if step == 0 {
+		step = 1
+	}
+	return r.Base + slot*step
+}
+
+func (r Resource) value(slot int) (string, error) {
+	switch r.Type {
+	case ResourcePort:
+		port := r.number(slot)
+		if port < 1 || port > 65535 {
+			return "", fmt.Errorf("port %d out of range", port)
+		}
+		return strconv.Itoa(port), nil
+	case ResourceNumber:
+		return strconv.Itoa(r.number(slot)), nil
+	case ResourceName:
+		if r.Prefix == "" {
+			return "", fmt.Errorf("name resources need a prefix")
+		}
+		if slot == 0 {
+			return r.Prefix, nil
+		}
+		return fmt.Sprintf("%s_wt_%d", r.Prefix, slot), nil
+	default:
+		return "", fmt.Errorf("unknown type %q", r.Type)
+	}
+}
+
+// ResourceEnv returns VIBEIT_SLOT and the resources allocated to a workspace
+// as NAME=value pairs
+func ResourceEnv(mainRepoPath, workspacePath string) ([]string, error) {
+	config, err := LoadConfig(mainRepoPath)
+	if err != nil {
+		return nil, err
+	}
+	slot := Slot(workspacePath)
+	values, err := config.Allocate(slot)
+	if err != nil {
+		return nil, err
+	}
+	return resourceEnv(slot, values), nil
+}
+
+// resourceEnv returns NAME=value pairs for allocated resources, sorted by name
+func resourceEnv(slot int, values map[string]string) []string {
+	env := []string{fmt.Sprintf("VIBEIT_SLOT=%d", slot)}
+	for _, name := range sortedKeys(values) {
+		env = append(env, fmt.Sprintf("%s=%s", name, values[name]))
+	}
+	return env
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
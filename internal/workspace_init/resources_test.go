@@ -0,0 +1,74 @@
+package workspace_init
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckOverlaps(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources map[string]Resource
+		conflict  string
+	}{
+		{
+			name: "disjoint ports",
+			resources: map[string]Resource{
+				"APP_PORT":  {Type: ResourcePort, Base: 3000},
+				"VITE_PORT": {Type: ResourcePort, Base: 5173},
+			},
+		},
+		{
+			name: "adjacent bases",
+			resources: map[string]Resource{
+				"APP_PORT":  {Type: ResourcePort, Base: 3000},
+				"VITE_PORT": {Type: ResourcePort, Base: 3001},
+			},
+			conflict: "APP_PORT and VITE_PORT overlap: both use 3001 (slots 1 and 0)",
+		},
+		{
+			name: "interleaved steps",
+			resources: map[string]Resource{
+				"APP_PORT": {Type: ResourcePort, Base: 3000, Step: 2},
+				"HMR_PORT": {Type: ResourcePort, Base: 3001, Step: 2},
+			},
+		},
+		{
+			name: "numbers",
+			resources: map[string]Resource{
+				"CACHE_DB": {Type: ResourceNumber, Base: 5},
+				"QUEUE_DB": {Type: ResourceNumber, Base: 0},
+			},
+			conflict: "CACHE_DB and QUEUE_DB overlap: both use 5 (slots 0 and 5)",
+		},
+		{
+			name: "different types",
+			resources: map[string]Resource{
+				"APP_PORT": {Type: ResourcePort, Base: 1},
+				"REDIS_DB": {Type: ResourceNumber, Base: 1},
+			},
+		},
+		{
+			name: "names",
+			resources: map[string]Resource{
+				"DB_NAME":   {Type: ResourceName, Prefix: "app"},
+				"TEST_NAME": {Type: ResourceName, Prefix: "app"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Config{Resources: tt.resources}.CheckOverlaps()
+			if tt.conflict == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.conflict) {
+				t.Fatalf("got %v, want %q", err, tt.conflict)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package workspace_init
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+const templateSuffix = ".tmpl"
+
+// TemplateData is the data available to files listed in the "templates" section.
+//
+//	APP_URL=http://localhost:{{.Resources.APP_PORT}}
+//	DB_DATABASE={{.Resources.DB_NAME}}
+//	# {{.Project}} workspace {{.Slot}} on {{.Branch}}
+type TemplateData struct {
+	Project   string
+	Workspace string
+	Slot      int
+	Branch    string
+	Path      string
+	MainPath  string
+	Resources map[string]string
+}
+
+// newTemplateData collects the template variables for a workspace
+func newTemplateData(mainRepoPath, workspacePath string, slot int, values map[string]string) TemplateData {
+	return TemplateData{
+		Project:   filepath.Base(mainRepoPath),
+		Workspace: filepath.Base(workspacePath),
+		Slot:      slot,
+		Branch:    currentBranch(workspacePath),
+		Path:      workspacePath,
+		MainPath:  mainRepoPath,
+		Resources: values,
+	}
+}
+
+// renderTemplate renders a template from the main repo into the workspace.
+// The destination drops the .tmpl suffix, e.g. ".env.tmpl" -> ".env".
+func renderTemplate(mainRepoPath, workspacePath, item string, data TemplateData) error {
+	src := filepath.Join(mainRepoPath, item)
+	if !strings.HasSuffix(item, templateSuffix) {
+		return fmt.Errorf("template must have a %s suffix", templateSuffix)
+	}
+	dst := filepath.Join(workspacePath, strings.TrimSuffix(item, templateSuffix))
+
+	content, err := os.ReadFile(src)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(src)
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := template.New(item).Option("missingkey=error").Parse(string(content))
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(dst, out.Bytes(), info.Mode())
+}
+
+func currentBranch(path string) string {
+	cmd := exec.Command("git", "-C", path, "rev-parse", "--abbrev-ref", "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
@@ -9,25 +9,20 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/emilianotisato/vibeit/internal/workspace"
 )
 
 // Config represents .vibe/wt.json
 type Config struct {
-	Before []string `json:"before"`
-	Copy   []string `json:"copy"`
-	After  []string `json:"after"`
-}
-
-const defaultConfig = `{
-    "before": [],
-    "copy": [
-        ".env",
-        "node_modules",
-        "vendor"
-    ],
-    "after": []
+	Before    []string            `json:"before"`
+	Copy      []string            `json:"copy"`
+	After     []string            `json:"after"`
+	Resources map[string]Resource `json:"resources,omitempty"`
+	Templates []string            `json:"templates,omitempty"`
+	Teardown  []string            `json:"teardown,omitempty"`
+	Hooks     map[string][]string `json:"hooks,omitempty"`
 }
-`
 
 const maxWorkspaces = 9
 
@@ -66,12 +61,13 @@ func Create(mainRepoPath, branchName, baseBranch string) (string, error) {
 		return "", fmt.Errorf("failed to set origin URL: %s: %w", string(output), err)
 	}
 
-	// Create and checkout the new branch from baseBranch
+	// Create and checkout the new branch from baseBranch (the clone's HEAD when empty)
 	var checkoutArgs []string
 	if baseBranch != "" {
 		checkoutArgs = []string{"checkout", "-b", branchName, baseBranch}
 	} else {
 		checkoutArgs = []string{"checkout", "-b", branchName}
+		baseBranch = currentBranch(workspacePath)
 	}
 	cmd = exec.Command("git", checkoutArgs...)
 	cmd.Dir = workspacePath
@@ -81,6 +77,14 @@ func Create(mainRepoPath, branchName, baseBranch string) (string, error) {
 		return "", fmt.Errorf("failed to create branch: %s: %w", string(output), err)
 	}
 
+	// Remember the base branch for notes templates and the session environment
+	cmd = exec.Command("git", "config", workspace.BaseBranchKey, baseBranch)
+	cmd.Dir = workspacePath
+	if output, err := cmd.CombinedOutput(); err != nil {
+		os.RemoveAll(workspacePath)
+		return "", fmt.Errorf("failed to record base branch: %s: %w", string(output), err)
+	}
+
 	return workspacePath, nil
 }
 
@@ -139,49 +143,64 @@ func ConfigExists(repoPath string) bool {
 	return err == nil
 }
 
-// EnsureConfig creates .vibe/wt.json with a default template when missing.
-func EnsureConfig(repoPath string) (string, bool, error) {
+// EnsureConfig creates .vibe/wt.json from the detected stack when missing.
+// It returns what Propose detected when it wrote the file (including "no known
+// stack detected" for the empty fallback), or nil when the file already existed.
+func EnsureConfig(repoPath string) (string, []string, error) {
 	path := ConfigPath(repoPath)
 	if _, err := os.Stat(path); err == nil {
-		return path, false, nil
+		return path, nil, nil
 	} else if !os.IsNotExist(err) {
-		return "", false, err
+		return "", nil, err
 	}
 
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-		return "", false, err
-	}
-	if err := os.WriteFile(path, []byte(defaultConfig), 0644); err != nil {
-		return "", false, err
+	config, findings := Propose(repoPath)
+	if _, err := WriteConfig(repoPath, config); err != nil {
+		return "", nil, err
 	}
 
-	return path, true, nil
+	return path, findings, nil
 }
 
-// Init initializes a workspace by running .vibe/wt.json config
-func Init(mainRepoPath, workspacePath string) error {
-	configPath := ConfigPath(mainRepoPath)
+// LoadConfig reads .vibe/wt.json, returning an empty config when it is missing
+func LoadConfig(repoPath string) (Config, error) {
+	var config Config
 
-	// Check if config exists
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
-		// No config file, nothing to do
-		return nil
+	data, err := os.ReadFile(ConfigPath(repoPath))
+	if os.IsNotExist(err) {
+		return config, nil
 	}
-
-	// Read config
-	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return fmt.Errorf("failed to read wt.json: %w", err)
+		return config, fmt.Errorf("failed to read wt.json: %w", err)
 	}
 
-	var config Config
 	if err := json.Unmarshal(data, &config); err != nil {
-		return fmt.Errorf("failed to parse wt.json: %w", err)
+		return config, fmt.Errorf("failed to parse wt.json: %w", err)
+	}
+	if err := config.CheckOverlaps(); err != nil {
+		return config, fmt.Errorf("wt.json: %w", err)
+	}
+	return config, nil
+}
+
+// Init initializes a workspace by running .vibe/wt.json config
+func Init(mainRepoPath, workspacePath string) error {
+	config, err := LoadConfig(mainRepoPath)
+	if err != nil {
+		return err
+	}
+
+	// Allocate per-workspace resources (ports, database names, ...)
+	slot := Slot(workspacePath)
+	values, err := config.Allocate(slot)
+	if err != nil {
+		return err
 	}
+	env := resourceEnv(slot, values)
 
 	// Run before commands
 	for _, cmdStr := range config.Before {
-		if err := runCommand(workspacePath, cmdStr); err != nil {
+		if err := runCommand(workspacePath, cmdStr, env); err != nil {
 			return fmt.Errorf("before command failed '%s': %w", cmdStr, err)
 		}
 	}
@@ -197,9 +216,17 @@ func Init(mainRepoPath, workspacePath string) error {
 		}
 	}
 
+	// Render templates from the main repo (e.g. .env.tmpl -> .env)
+	tmplData := newTemplateData(mainRepoPath, workspacePath, slot, values)
+	for _, item := range config.Templates {
+		if err := renderTemplate(mainRepoPath, workspacePath, item, tmplData); err != nil {
+			return fmt.Errorf("failed to render template %s: %w", item, err)
+		}
+	}
+
 	// Run after commands
 	for _, cmdStr := range config.After {
-		if err := runCommand(workspacePath, cmdStr); err != nil {
+		if err := runCommand(workspacePath, cmdStr, env); err != nil {
 			return fmt.Errorf("after command failed '%s': %w", cmdStr, err)
 		}
 	}
@@ -207,9 +234,53 @@ func Init(mainRepoPath, workspacePath string) error {
 	return nil
 }
 
-func runCommand(dir, cmdStr string) error {
+// Teardown runs the .vibe/wt.json teardown commands inside a workspace
+func Teardown(mainRepoPath, workspacePath string) error {
+	config, err := LoadConfig(mainRepoPath)
+	if err != nil {
+		return err
+	}
+
+	slot := Slot(workspacePath)
+	values, err := config.Allocate(slot)
+	if err != nil {
+		return err
+	}
+	env := resourceEnv(slot, values)
+
+	for _, cmdStr := range config.Teardown {
+		if err := runCommand(workspacePath, cmdStr, env); err != nil {
+			return fmt.Errorf("teardown command failed '%s': %w", cmdStr, err)
+		}
+	}
+
+	return nil
+}
+
+// Remove runs teardown commands and deletes a {projectName}-wt-N workspace.
+// When force is set, teardown failures are reported but don't stop the removal.
+func Remove(mainRepoPath, workspacePath string, force bool) error {
+	if Slot(workspacePath) == 0 {
+		return fmt.Errorf("%s is not a workspace clone", workspacePath)
+	}
+	if _, err := os.Stat(filepath.Join(workspacePath, ".git")); err != nil {
+		return fmt.Errorf("%s is not a git repository", workspacePath)
+	}
+
+	if err := Teardown(mainRepoPath, workspacePath); err != nil {
+		if !force {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	return os.RemoveAll(workspacePath)
+}
+
+func runCommand(dir, cmdStr string, env []string) error {
 	cmd := exec.Command("sh", "-c", cmdStr)
 	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), env...)
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 	return cmd.Run()